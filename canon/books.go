@@ -0,0 +1,79 @@
+package canon
+
+const (
+	TestamentOld = "OT"
+	TestamentNew = "NT"
+)
+
+var (
+	// Books lists the canonical books in order, mirroring the books table;
+	// Code is the OSIS ID and USFMCode the USFM/Paratext ID
+	Books = []*Book{
+		{1, "Gen", "GEN", TestamentOld, 50},
+		{2, "Exod", "EXO", TestamentOld, 40},
+		{3, "Lev", "LEV", TestamentOld, 27},
+		{4, "Num", "NUM", TestamentOld, 36},
+		{5, "Deut", "DEU", TestamentOld, 34},
+		{6, "Josh", "JOS", TestamentOld, 24},
+		{7, "Judg", "JDG", TestamentOld, 21},
+		{8, "Ruth", "RUT", TestamentOld, 4},
+		{9, "1Sam", "1SA", TestamentOld, 31},
+		{10, "2Sam", "2SA", TestamentOld, 24},
+		{11, "1Kgs", "1KI", TestamentOld, 22},
+		{12, "2Kgs", "2KI", TestamentOld, 25},
+		{13, "1Chr", "1CH", TestamentOld, 29},
+		{14, "2Chr", "2CH", TestamentOld, 36},
+		{15, "Ezra", "EZR", TestamentOld, 10},
+		{16, "Neh", "NEH", TestamentOld, 13},
+		{17, "Esth", "EST", TestamentOld, 10},
+		{18, "Job", "JOB", TestamentOld, 42},
+		{19, "Ps", "PSA", TestamentOld, 150},
+		{20, "Prov", "PRO", TestamentOld, 31},
+		{21, "Eccl", "ECC", TestamentOld, 12},
+		{22, "Song", "SNG", TestamentOld, 8},
+		{23, "Isa", "ISA", TestamentOld, 66},
+		{24, "Jer", "JER", TestamentOld, 52},
+		{25, "Lam", "LAM", TestamentOld, 5},
+		{26, "Ezek", "EZK", TestamentOld, 48},
+		{27, "Dan", "DAN", TestamentOld, 12},
+		{28, "Hos", "HOS", TestamentOld, 14},
+		{29, "Joel", "JOL", TestamentOld, 3},
+		{30, "Amos", "AMO", TestamentOld, 9},
+		{31, "Obad", "OBA", TestamentOld, 1},
+		{32, "Jonah", "JON", TestamentOld, 4},
+		{33, "Mic", "MIC", TestamentOld, 7},
+		{34, "Nah", "NAM", TestamentOld, 3},
+		{35, "Hab", "HAB", TestamentOld, 3},
+		{36, "Zeph", "ZEP", TestamentOld, 3},
+		{37, "Hag", "HAG", TestamentOld, 2},
+		{38, "Zech", "ZEC", TestamentOld, 14},
+		{39, "Mal", "MAL", TestamentOld, 4},
+		{40, "Matt", "MAT", TestamentNew, 28},
+		{41, "Mark", "MRK", TestamentNew, 16},
+		{42, "Luke", "LUK", TestamentNew, 24},
+		{43, "John", "JHN", TestamentNew, 21},
+		{44, "Acts", "ACT", TestamentNew, 28},
+		{45, "Rom", "ROM", TestamentNew, 16},
+		{46, "1Cor", "1CO", TestamentNew, 16},
+		{47, "2Cor", "2CO", TestamentNew, 13},
+		{48, "Gal", "GAL", TestamentNew, 6},
+		{49, "Eph", "EPH", TestamentNew, 6},
+		{50, "Phil", "PHP", TestamentNew, 4},
+		{51, "Col", "COL", TestamentNew, 4},
+		{52, "1Thess", "1TH", TestamentNew, 5},
+		{53, "2Thess", "2TH", TestamentNew, 3},
+		{54, "1Tim", "1TI", TestamentNew, 6},
+		{55, "2Tim", "2TI", TestamentNew, 4},
+		{56, "Titus", "TIT", TestamentNew, 3},
+		{57, "Phlm", "PHM", TestamentNew, 1},
+		{58, "Heb", "HEB", TestamentNew, 13},
+		{59, "Jas", "JAS", TestamentNew, 5},
+		{60, "1Pet", "1PE", TestamentNew, 5},
+		{61, "2Pet", "2PE", TestamentNew, 3},
+		{62, "1John", "1JN", TestamentNew, 5},
+		{63, "2John", "2JN", TestamentNew, 1},
+		{64, "3John", "3JN", TestamentNew, 1},
+		{65, "Jude", "JUD", TestamentNew, 1},
+		{66, "Rev", "REV", TestamentNew, 22},
+	}
+)
@@ -0,0 +1,215 @@
+package canon
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-json"
+	"github.com/roysitumorang/bible/reference"
+)
+
+type (
+	Book struct {
+		ID        int    `json:"id"`
+		Code      string `json:"code"`
+		USFMCode  string `json:"usfm_code"`
+		Testament string `json:"testament"`
+		Chapters  int    `json:"chapters"`
+	}
+
+	// Name is the localized name of a book in one language; every alias,
+	// the name and the abbreviation resolve to the book
+	Name struct {
+		Code         string   `json:"code"`
+		Language     string   `json:"-"`
+		Name         string   `json:"name"`
+		Abbreviation string   `json:"abbreviation"`
+		Aliases      []string `json:"aliases"`
+	}
+
+	// Registry maps canonical book codes to localized names and resolves
+	// typed names in any loaded language back to books
+	Registry struct {
+		mu      sync.RWMutex
+		books   map[string]*Book
+		refs    map[string]*reference.Book
+		names   map[string]map[string]*Name
+		aliases map[string]string
+		keys    []string
+	}
+
+	languageFile struct {
+		Language string  `json:"language"`
+		Books    []*Name `json:"books"`
+	}
+)
+
+const (
+	DefaultLanguage = "en"
+)
+
+var (
+	//go:embed data/*.json
+	data embed.FS
+
+	Default = sync.OnceValues(func() (*Registry, error) {
+		registry := NewRegistry()
+		if err := registry.LoadFS(data, "data"); err != nil {
+			return nil, err
+		}
+		return registry, nil
+	})
+)
+
+func NewRegistry() *Registry {
+	registry := &Registry{
+		books:   map[string]*Book{},
+		refs:    map[string]*reference.Book{},
+		names:   map[string]map[string]*Name{},
+		aliases: map[string]string{},
+	}
+	for _, book := range Books {
+		registry.books[book.Code] = book
+		registry.refs[book.Code] = &reference.Book{
+			Code:     book.Code,
+			Order:    book.ID,
+			Chapters: book.Chapters,
+		}
+		registry.addAlias(book.Code, book.Code)
+		registry.addAlias(book.USFMCode, book.Code)
+	}
+	return registry
+}
+
+// LoadFS loads every *.json language file found in dir
+func (r *Registry) LoadFS(fsys fs.FS, dir string) error {
+	paths, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, filepath := range paths {
+		content, err := fs.ReadFile(fsys, filepath)
+		if err != nil {
+			return err
+		}
+		var file languageFile
+		if err := json.Unmarshal(content, &file); err != nil {
+			return fmt.Errorf("canon: %s: %w", filepath, err)
+		}
+		if file.Language == "" {
+			return fmt.Errorf("canon: %s: language is required", filepath)
+		}
+		for _, name := range file.Books {
+			name.Language = file.Language
+		}
+		if err := r.Add(file.Books...); err != nil {
+			return fmt.Errorf("canon: %s: %w", filepath, err)
+		}
+	}
+	return nil
+}
+
+// Add registers localized names, replacing earlier names of the same
+// book and language; aliases already taken by another book are ignored
+func (r *Registry) Add(names ...*Name) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, name := range names {
+		if _, ok := r.books[name.Code]; !ok {
+			return fmt.Errorf("unknown book code %q", name.Code)
+		}
+		if name.Language == "" {
+			return fmt.Errorf("%s: language is required", name.Code)
+		}
+		if _, ok := r.names[name.Language]; !ok {
+			r.names[name.Language] = map[string]*Name{}
+		}
+		r.names[name.Language][name.Code] = name
+		r.addAlias(name.Name, name.Code)
+		r.addAlias(name.Abbreviation, name.Code)
+		for _, alias := range name.Aliases {
+			r.addAlias(alias, name.Code)
+		}
+	}
+	return nil
+}
+
+func (r *Registry) addAlias(alias, code string) {
+	key := reference.NormalizeBookName(alias)
+	if key == "" {
+		return
+	}
+	if _, ok := r.aliases[key]; ok {
+		return
+	}
+	r.aliases[key] = code
+	i := sort.SearchStrings(r.keys, key)
+	r.keys = append(r.keys, "")
+	copy(r.keys[i+1:], r.keys[i:])
+	r.keys[i] = key
+}
+
+// ResolveBook matches an exact alias first, then an alias prefix that
+// points to a single book ("Gene" for Genesis)
+func (r *Registry) ResolveBook(name string) (*reference.Book, bool) {
+	key := reference.NormalizeBookName(name)
+	if key == "" {
+		return nil, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if code, ok := r.aliases[key]; ok {
+		return r.refs[code], true
+	}
+	var match string
+	for i := sort.SearchStrings(r.keys, key); i < len(r.keys) && strings.HasPrefix(r.keys[i], key); i++ {
+		code := r.aliases[r.keys[i]]
+		if match != "" && match != code {
+			return nil, false
+		}
+		match = code
+	}
+	if match == "" {
+		return nil, false
+	}
+	return r.refs[match], true
+}
+
+func (r *Registry) Book(code string) (*Book, bool) {
+	book, ok := r.books[code]
+	return book, ok
+}
+
+// Name returns the book name in language, falling back to English and
+// then to the book code
+func (r *Registry) Name(code, language string) *Name {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, lang := range []string{language, DefaultLanguage} {
+		if name, ok := r.names[lang][code]; ok {
+			return name
+		}
+	}
+	return &Name{
+		Code:         code,
+		Language:     language,
+		Name:         code,
+		Abbreviation: code,
+	}
+}
+
+func (r *Registry) Languages() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	languages := make([]string, 0, len(r.names))
+	for language := range r.names {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
@@ -14,7 +14,9 @@ require (
 	github.com/spf13/cobra v1.8.1
 	github.com/sqids/sqids-go v0.4.1
 	go.uber.org/zap v1.27.0
+	golang.org/x/crypto v0.29.0
 	golang.org/x/sync v0.9.0
+	golang.org/x/text v0.20.0
 )
 
 require (
@@ -34,7 +36,5 @@ require (
 	github.com/valyala/fasthttp v1.57.0 // indirect
 	github.com/valyala/tcplisten v1.0.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
-	golang.org/x/crypto v0.29.0 // indirect
 	golang.org/x/sys v0.27.0 // indirect
-	golang.org/x/text v0.20.0 // indirect
 )
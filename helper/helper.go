@@ -89,6 +89,13 @@ func LoadTimeZone() *time.Location {
 	return timeZone
 }
 
+// Day is the calendar day of t in LoadTimeZone, as stored in a date
+// column
+func Day(t time.Time) time.Time {
+	year, month, date := t.In(timeZone).Date()
+	return time.Date(year, month, date, 0, 0, 0, 0, time.UTC)
+}
+
 func GetEnv() string {
 	env := os.Getenv("ENV")
 	if env == "" {
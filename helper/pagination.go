@@ -0,0 +1,144 @@
+package helper
+
+import (
+	"encoding/base64"
+	"math"
+	"net/url"
+	"strconv"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/roysitumorang/bible/models"
+)
+
+// ParsePage reads the page and per_page query parameters, per_page being
+// one of models.PerPageRowsCount; page is capped so that its rows stay
+// within the int32 OFFSET of PostgreSQL
+func ParsePage(c *fiber.Ctx, defaultPerPage int) (page, perPage int, err error) {
+	if perPage, err = ParsePerPage(c, defaultPerPage); err != nil {
+		return 0, 0, err
+	}
+	page = 1
+	if value := c.Query("page"); value != "" {
+		number, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || number < 1 {
+			return 0, 0, models.ErrInvalidPage
+		}
+		page = int(number)
+	}
+	if page > math.MaxInt32/perPage {
+		return 0, 0, models.ErrPageTooLarge
+	}
+	return page, perPage, nil
+}
+
+// ParsePerPage reads the per_page query parameter, rejecting anything but
+// one of models.PerPageRowsCount
+func ParsePerPage(c *fiber.Ctx, defaultPerPage int) (int, error) {
+	perPage := defaultPerPage
+	if value := c.Query("per_page"); value != "" {
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, models.ErrInvalidPerPage
+		}
+		perPage = number
+	}
+	if _, ok := models.MapPerPageRowsCount[perPage]; !ok {
+		return 0, models.ErrInvalidPerPage
+	}
+	return perPage, nil
+}
+
+// Offset is the number of rows before page
+func Offset(page, perPage int) int {
+	return (page - 1) * perPage
+}
+
+// Total returns the number of rows of a paged listing, calling count only
+// when the page cannot tell: a page shorter than perPage is the last one,
+// unless it is empty past the first page
+func Total(page, perPage, rows int, count func() (int, error)) (int, error) {
+	if rows < perPage && (rows > 0 || page == 1) {
+		return Offset(page, perPage) + rows, nil
+	}
+	return count()
+}
+
+// NewPagination links the pages of a listing to the request URL, keeping
+// its other query parameters
+func NewPagination(c *fiber.Ctx, page, perPage, total int) *models.Pagination {
+	var response models.Pagination
+	response.Info.PerPage = perPage
+	response.Info.Total = total
+	response.Info.Pages = int(math.Ceil(float64(total) / float64(perPage)))
+	link := func(page int) string {
+		return pageURL(c, map[string]string{
+			"page":     strconv.Itoa(page),
+			"per_page": strconv.Itoa(perPage),
+		})
+	}
+	response.Links.First = link(1)
+	response.Links.Current = link(page)
+	if page > 1 {
+		response.Links.Previous = link(page - 1)
+	}
+	if page < response.Info.Pages {
+		response.Links.Next = link(page + 1)
+	}
+	return &response
+}
+
+// NewCursorPagination links the current page of a keyset listing and,
+// unless nextCursor is empty, the next one
+func NewCursorPagination(c *fiber.Ctx, perPage int, nextCursor string) *models.CursorPagination {
+	var response models.CursorPagination
+	response.Info.PerPage = perPage
+	response.Info.NextCursor = nextCursor
+	response.Links.Current = pageURL(c, map[string]string{
+		"per_page": strconv.Itoa(perPage),
+	})
+	if nextCursor != "" {
+		response.Links.Next = pageURL(c, map[string]string{
+			"cursor":   nextCursor,
+			"per_page": strconv.Itoa(perPage),
+		})
+	}
+	return &response
+}
+
+// EncodeCursor packs the sort key of the last row of a page into an
+// opaque URL safe cursor
+func EncodeCursor(values ...interface{}) (string, error) {
+	content, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(content), nil
+}
+
+// DecodeCursor unpacks a cursor of EncodeCursor into pointers to the
+// values it was made of, in the same order
+func DecodeCursor(cursor string, values ...interface{}) error {
+	content, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return models.ErrInvalidCursor
+	}
+	var fields []json.RawMessage
+	if err := json.Unmarshal(content, &fields); err != nil || len(fields) != len(values) {
+		return models.ErrInvalidCursor
+	}
+	for i, field := range fields {
+		if err := json.Unmarshal(field, values[i]); err != nil {
+			return models.ErrInvalidCursor
+		}
+	}
+	return nil
+}
+
+func pageURL(c *fiber.Ctx, params map[string]string) string {
+	values, _ := url.ParseQuery(string(c.Request().URI().QueryString()))
+	for key, value := range params {
+		values.Set(key, value)
+	}
+	return c.BaseURL() + c.Path() + "?" + values.Encode()
+}
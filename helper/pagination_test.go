@@ -0,0 +1,60 @@
+package helper
+
+import (
+	"errors"
+	"math"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/roysitumorang/bible/models"
+)
+
+func TestParsePage(t *testing.T) {
+	tests := []struct {
+		query   string
+		page    int
+		perPage int
+		err     error
+	}{
+		{query: "", page: 1, perPage: 25},
+		{query: "page=3&per_page=10", page: 3, perPage: 10},
+		{query: "page=0", err: models.ErrInvalidPage},
+		{query: "page=-2", err: models.ErrInvalidPage},
+		{query: "page=abc", err: models.ErrInvalidPage},
+		{query: "page=1.5", err: models.ErrInvalidPage},
+		{query: "page=99999999999999999999", err: models.ErrInvalidPage},
+		{query: "per_page=abc", err: models.ErrInvalidPerPage},
+		{query: "per_page=30", err: models.ErrInvalidPerPage},
+		{query: "page=2&per_page=abc", err: models.ErrInvalidPerPage},
+		{query: "page=" + strconv.Itoa(math.MaxInt32/100) + "&per_page=100", page: math.MaxInt32 / 100, perPage: 100},
+		{query: "page=" + strconv.Itoa(math.MaxInt32/100+1) + "&per_page=100", err: models.ErrPageTooLarge},
+		{query: "page=" + strconv.Itoa(math.MaxInt32), err: models.ErrPageTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			var (
+				page, perPage int
+				err           error
+			)
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				page, perPage, err = ParsePage(c, 25)
+				return nil
+			})
+			if _, testErr := app.Test(httptest.NewRequest(fiber.MethodGet, "/?"+tt.query, nil)); testErr != nil {
+				t.Fatal(testErr)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ParsePage(%q) error = %v, want %v", tt.query, err, tt.err)
+			}
+			if page != tt.page || perPage != tt.perPage {
+				t.Errorf("ParsePage(%q) = %d, %d, want %d, %d", tt.query, page, perPage, tt.page, tt.perPage)
+			}
+			if err == nil && Offset(page, perPage) < 0 {
+				t.Errorf("Offset(%d, %d) overflows", page, perPage)
+			}
+		})
+	}
+}
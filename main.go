@@ -1,15 +1,29 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/joho/godotenv"
 	"github.com/robfig/cron/v3"
 	"github.com/roysitumorang/bible/config"
 	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/migration"
+	concordanceModel "github.com/roysitumorang/bible/modules/concordance/model"
+	exporterModel "github.com/roysitumorang/bible/modules/exporter/model"
+	importerModel "github.com/roysitumorang/bible/modules/importer/model"
+	"github.com/roysitumorang/bible/modules/importer/reader"
+	readingPlanModel "github.com/roysitumorang/bible/modules/readingplan/model"
 	"github.com/roysitumorang/bible/router"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -49,13 +63,42 @@ func main() {
 				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrMigrate")
 				return
 			}
+			if err := service.ScriptureUseCase.LoadBookNames(ctx); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoadBookNames")
+				return
+			}
 			g.Go(func() error {
 				return service.HTTPServerMain(ctx)
 			})
 			g.Go(func() error {
-				c := cron.New(cron.WithChain(
-					cron.Recover(cron.DefaultLogger),
-				))
+				c := cron.New(
+					cron.WithLocation(helper.LoadTimeZone()),
+					cron.WithChain(
+						cron.Recover(cron.DefaultLogger),
+					),
+				)
+				if _, err := c.AddFunc(service.VotdConfig.Schedule, func() {
+					if err := service.VotdUseCase.PickAll(ctx, time.Now()); err != nil {
+						helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrPickAll")
+					}
+				}); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrAddFunc")
+					return err
+				}
+				// a translation is imported by the CLI, so its concordance is
+				// built here once the app notices it is stale
+				if _, err := c.AddJob(service.ConcordanceConfig.Schedule, cron.NewChain(
+					cron.SkipIfStillRunning(cron.DefaultLogger),
+				).Then(cron.FuncJob(func() {
+					buildConcordances(ctx, service)
+				}))); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrAddJob")
+					return err
+				}
+				// today's verse may have been missed while the app was down
+				if err := service.VotdUseCase.PickAll(ctx, time.Now()); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrPickAll")
+				}
 				c.Start()
 				helper.Log(ctx, zap.InfoLevel, "cron: scheduled tasks running!...", ctxt, "")
 				return nil
@@ -65,15 +108,39 @@ func main() {
 			}
 		},
 	}
+	var migrationDryRun bool
 	cmdMigration := &cobra.Command{
 		Use:   "migration",
-		Short: "new/run migration",
+		Short: "new [sql]/run/status migration, down [n] to revert the last n (default 1), to <version> to migrate up or down to version",
 		Args: func(_ *cobra.Command, args []string) (err error) {
 			if len(args) == 0 {
-				err = errors.New("requires at least 1 arg (new|run")
+				err = errors.New("requires at least 1 arg (new|run|status|down|to)")
 				return
 			}
-			if args[0] != "new" && args[0] != "run" {
+			switch args[0] {
+			case "new":
+				if len(args) > 2 || len(args) == 2 && args[1] != "sql" {
+					err = errors.New("new accepts at most 1 arg (sql)")
+				}
+			case "run", "status":
+				if len(args) > 1 {
+					err = fmt.Errorf("%s accepts no args", args[0])
+				}
+			case "down":
+				if len(args) > 2 {
+					err = errors.New("down accepts at most 1 arg (n)")
+				} else if len(args) == 2 {
+					if n, errAtoi := strconv.Atoi(args[1]); errAtoi != nil || n < 1 {
+						err = fmt.Errorf("invalid number of migrations to revert: %s", args[1])
+					}
+				}
+			case "to":
+				if len(args) != 2 {
+					err = errors.New("to requires 1 arg (version)")
+				} else if version, errParse := strconv.ParseInt(args[1], 10, 64); errParse != nil || version < 0 {
+					err = fmt.Errorf("invalid version: %s", args[1])
+				}
+			default:
 				err = fmt.Errorf("invalid first flag specified: %s", args[0])
 			}
 			return
@@ -95,30 +162,570 @@ func main() {
 			}
 			switch args[0] {
 			case "new":
-				if err := service.Migration.CreateMigrationFile(ctx); err != nil {
+				if len(args) > 1 {
+					if err := service.Migration.CreateSQLMigrationFile(ctx); err != nil {
+						helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCreateSQLMigrationFile")
+						return
+					}
+				} else if err := service.Migration.CreateMigrationFile(ctx); err != nil {
 					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCreateMigrationFile")
 					return
 				}
 				duration := time.Since(now)
 				helper.Log(ctx, zap.InfoLevel, fmt.Sprintf("creating migration successfully in %s", duration.String()), ctxt, "")
 			case "run":
+				if migrationDryRun {
+					versions, err := service.Migration.Plan(ctx)
+					if err != nil {
+						helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrPlan")
+						return
+					}
+					for _, version := range versions {
+						fmt.Printf("%d would be applied\n", version)
+					}
+					helper.Log(ctx, zap.InfoLevel, fmt.Sprintf("dry run: %d pending migration(s), nothing applied", len(versions)), ctxt, "")
+					return
+				}
 				if err := service.Migration.Migrate(ctx); err != nil {
 					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrMigrate")
 					return
 				}
 				duration := time.Since(now)
 				helper.Log(ctx, zap.InfoLevel, fmt.Sprintf("running migration successfully in %s", duration.String()), ctxt, "")
+			case "status":
+				statuses, err := service.Migration.Status(ctx)
+				if err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrStatus")
+					return
+				}
+				var pending int
+				for _, status := range statuses {
+					appliedAt := "-"
+					if status.AppliedAt != nil {
+						appliedAt = status.AppliedAt.In(helper.LoadTimeZone()).Format(time.RFC3339)
+					}
+					if status.State == migration.StatePending {
+						pending++
+					}
+					fmt.Printf("%-20d %-8s %s\n", status.Version, status.State, appliedAt)
+				}
+				helper.Log(ctx, zap.InfoLevel, fmt.Sprintf("%d migration(s), %d pending", len(statuses), pending), ctxt, "")
+			case "down":
+				steps := 1
+				if len(args) > 1 {
+					steps, _ = strconv.Atoi(args[1])
+				}
+				if err := service.Migration.Rollback(ctx, steps); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrRollback")
+					return
+				}
+				duration := time.Since(now)
+				helper.Log(ctx, zap.InfoLevel, fmt.Sprintf("reverting up to %d migration(s) successfully in %s", steps, duration.String()), ctxt, "")
+			case "to":
+				version, _ := strconv.ParseInt(args[1], 10, 64)
+				if err := service.Migration.MigrateTo(ctx, version); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrMigrateTo")
+					return
+				}
+				duration := time.Since(now)
+				helper.Log(ctx, zap.InfoLevel, fmt.Sprintf("migrating to version %d successfully in %s", version, duration.String()), ctxt, "")
 			}
 		},
 	}
+	cmdMigration.Flags().BoolVar(&migrationDryRun, "dry-run", false, "with run, list the pending migrations without applying them")
+	var (
+		importRequest                    importerModel.Request
+		importDescription, importLicense string
+	)
+	cmdImport := &cobra.Command{
+		Use:   "import",
+		Short: "import translation (osis|usfm|usx|zefania|csv)",
+		PersistentPreRun: func(_ *cobra.Command, _ []string) {
+			if importDescription != "" {
+				importRequest.Translation.Description = &importDescription
+			}
+			if importLicense != "" {
+				importRequest.Translation.License = &importLicense
+			}
+		},
+	}
+	cmdImport.PersistentFlags().StringVar(&importRequest.Translation.Code, "code", "", "translation code, e.g. kjv (defaults to the one in the file)")
+	cmdImport.PersistentFlags().StringVar(&importRequest.Translation.Name, "name", "", "translation name (defaults to the one in the file)")
+	cmdImport.PersistentFlags().StringVar(&importRequest.Translation.Language, "language", "", "language code, e.g. id or en (defaults to the one in the file)")
+	cmdImport.PersistentFlags().StringVar(&importDescription, "description", "", "translation description")
+	cmdImport.PersistentFlags().StringVar(&importLicense, "license", "", "translation license or copyright notice")
+	cmdImport.PersistentFlags().StringVar(&importRequest.Translation.TextSearchConfig, "search-config", "", "PostgreSQL text search configuration (defaults by language, 'simple' otherwise)")
+	cmdImport.PersistentFlags().StringVar(&importRequest.Translation.Versification, "versification", "", "verse numbering scheme: kjv, mt, lxx or vulgate (default kjv)")
+	cmdImport.PersistentFlags().BoolVar(&importRequest.Overwrite, "overwrite", false, "replace the verses of an existing translation")
+	cmdImport.AddCommand(
+		&cobra.Command{
+			Use:   "osis <file>",
+			Short: "import OSIS XML file",
+			Args:  cobra.ExactArgs(1),
+			Run: func(_ *cobra.Command, args []string) {
+				file, err := os.Open(args[0])
+				if err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrOpen")
+					return
+				}
+				defer file.Close()
+				importTranslation(ctx, reader.NewOSISReader(file), &importRequest)
+			},
+		},
+		&cobra.Command{
+			Use:   "usfm <file|dir|zip>",
+			Short: "import USFM files (*.usfm, *.sfm), one book per file",
+			Args:  cobra.ExactArgs(1),
+			Run: func(_ *cobra.Command, args []string) {
+				fsys, paths, closer, err := reader.Open(args[0], ".usfm", ".sfm")
+				if err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrOpen")
+					return
+				}
+				defer closer.Close()
+				importTranslation(ctx, reader.NewUSFMReader(fsys, paths), &importRequest)
+			},
+		},
+		&cobra.Command{
+			Use:   "usx <file|dir|zip>",
+			Short: "import USX files (*.usx), one book per file",
+			Args:  cobra.ExactArgs(1),
+			Run: func(_ *cobra.Command, args []string) {
+				fsys, paths, closer, err := reader.Open(args[0], ".usx")
+				if err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrOpen")
+					return
+				}
+				defer closer.Close()
+				importTranslation(ctx, reader.NewUSXReader(fsys, paths), &importRequest)
+			},
+		},
+		&cobra.Command{
+			Use:   "zefania <file>",
+			Short: "import Zefania XML file",
+			Args:  cobra.ExactArgs(1),
+			Run: func(_ *cobra.Command, args []string) {
+				file, err := os.Open(args[0])
+				if err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrOpen")
+					return
+				}
+				defer file.Close()
+				importTranslation(ctx, reader.NewZefaniaReader(file), &importRequest)
+			},
+		},
+	)
+	var (
+		csvOptions               reader.CSVOptions
+		csvDelimiter, csvColumns string
+	)
+	cmdImportCSV := &cobra.Command{
+		Use:   "csv <file>",
+		Short: "import CSV/TSV file with book, chapter, verse and text columns",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			switch {
+			case csvDelimiter == "tab" || csvDelimiter == `\t`:
+				csvOptions.Comma = '\t'
+			case csvDelimiter != "":
+				csvOptions.Comma, _ = utf8.DecodeRuneInString(csvDelimiter)
+			case strings.EqualFold(filepath.Ext(args[0]), ".tsv"):
+				csvOptions.Comma = '\t'
+			}
+			csvOptions.Columns = reader.ParseColumns(csvColumns)
+			file, err := os.Open(args[0])
+			if err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrOpen")
+				return
+			}
+			defer file.Close()
+			importTranslation(ctx, reader.NewCSVReader(file, csvOptions), &importRequest)
+		},
+	}
+	cmdImportCSV.Flags().StringVar(&csvDelimiter, "delimiter", "", `column delimiter, "tab" for TSV (default "," or tab for *.tsv)`)
+	cmdImportCSV.Flags().BoolVar(&csvOptions.Header, "header", false, "first row is a header naming the columns")
+	cmdImportCSV.Flags().StringVar(&csvColumns, "columns", "", `columns in file order, e.g. "id,book,chapter,verse,text" (unknown names are skipped)`)
+	cmdImport.AddCommand(cmdImportCSV)
+	var (
+		exportRequest exporterModel.Request
+		exportOutput  string
+	)
+	cmdExport := &cobra.Command{
+		Use:   "export <translation>",
+		Short: "export translation (json|csv|osis)",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			exportRequest.Translation = args[0]
+			if err := godotenv.Load(".env"); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoad")
+				return
+			}
+			if err := helper.InitHelper(); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrInitHelper")
+				return
+			}
+			service, err := router.MakeHandler(ctx)
+			if err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrMakeHandler")
+				return
+			}
+			if err := service.ScriptureUseCase.LoadBookNames(ctx); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoadBookNames")
+				return
+			}
+			output := os.Stdout
+			if exportOutput != "" && exportOutput != "-" {
+				if output, err = os.Create(exportOutput); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCreate")
+					return
+				}
+				defer output.Close()
+			}
+			buffer := bufio.NewWriter(output)
+			report, err := service.ExporterUseCase.Export(ctx, &exportRequest, buffer)
+			if err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExport")
+				return
+			}
+			if err := buffer.Flush(); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFlush")
+				return
+			}
+			helper.Log(ctx, zap.InfoLevel, fmt.Sprintf("exporting %s as %s successfully: %d verses in %s", report.Translation, report.Format, report.Verses, report.Duration.String()), ctxt, "")
+		},
+	}
+	cmdExport.Flags().StringVarP(&exportRequest.Format, "format", "f", exporterModel.FormatJSON, "output format: json, csv or osis")
+	cmdExport.Flags().StringVarP(&exportRequest.Reference, "ref", "r", "", `reference range to export, e.g. "Gen 1-11" (default whole translation)`)
+	cmdExport.Flags().StringVarP(&exportOutput, "output", "o", "", "output file (default stdout)")
+	cmdPlan := &cobra.Command{
+		Use:   "plan",
+		Short: "manage reading plans",
+	}
+	cmdPlan.AddCommand(&cobra.Command{
+		Use:   "load [dir]",
+		Short: "load reading plans from the *.json files of dir (default built-in plans)",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			now := time.Now()
+			var (
+				fsys fs.FS = readingPlanModel.Data
+				dir        = "data"
+			)
+			if len(args) > 0 {
+				fsys, dir = os.DirFS(args[0]), "."
+			}
+			if err := godotenv.Load(".env"); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoad")
+				return
+			}
+			if err := helper.InitHelper(); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrInitHelper")
+				return
+			}
+			service, err := router.MakeHandler(ctx)
+			if err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrMakeHandler")
+				return
+			}
+			if err := service.ScriptureUseCase.LoadBookNames(ctx); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoadBookNames")
+				return
+			}
+			reports, err := service.ReadingPlanUseCase.LoadPlans(ctx, fsys, dir)
+			if err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoadPlans")
+				return
+			}
+			for _, report := range reports {
+				fmt.Printf("%-24s %5d days %6d readings\n", report.Plan, report.Days, report.Readings)
+			}
+			helper.Log(ctx, zap.InfoLevel, fmt.Sprintf("loading %d reading plans successfully in %s", len(reports), time.Since(now).String()), ctxt, "")
+		},
+	})
+	cmdLexicon := &cobra.Command{
+		Use:   "lexicon",
+		Short: "manage the Strong's lexicon",
+	}
+	cmdLexicon.AddCommand(&cobra.Command{
+		Use:   "import <file>...",
+		Short: "import Strong's Hebrew and Greek dictionaries (Open Scriptures JSON or JS files)",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			if err := godotenv.Load(".env"); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoad")
+				return
+			}
+			if err := helper.InitHelper(); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrInitHelper")
+				return
+			}
+			service, err := router.MakeHandler(ctx)
+			if err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrMakeHandler")
+				return
+			}
+			for _, path := range args {
+				if err := importLexicon(ctx, service, path); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrImportLexicon")
+					return
+				}
+			}
+		},
+	})
+	cmdCrossRef := &cobra.Command{
+		Use:   "crossref",
+		Short: "manage the cross reference dataset",
+	}
+	cmdCrossRef.AddCommand(&cobra.Command{
+		Use:   "import <file>",
+		Short: "replace the cross references with a tab separated from/to/votes file (OpenBible.info format)",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			if err := godotenv.Load(".env"); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoad")
+				return
+			}
+			if err := helper.InitHelper(); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrInitHelper")
+				return
+			}
+			file, err := os.Open(args[0])
+			if err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrOpen")
+				return
+			}
+			defer file.Close()
+			service, err := router.MakeHandler(ctx)
+			if err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrMakeHandler")
+				return
+			}
+			if err := service.ScriptureUseCase.LoadBookNames(ctx); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoadBookNames")
+				return
+			}
+			report, err := service.CrossRefUseCase.Import(ctx, file)
+			if err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrImport")
+				return
+			}
+			helper.Log(ctx, zap.InfoLevel, fmt.Sprintf("importing cross references successfully: %d rows, %d skipped, %d from ranges skipped in %s", report.Rows, report.Skipped, report.RangedSources, report.Duration.String()), ctxt, "")
+		},
+	})
+	var audioTranslation string
+	cmdAudio := &cobra.Command{
+		Use:   "audio",
+		Short: "manage audio bible recordings",
+	}
+	cmdAudioImport := &cobra.Command{
+		Use:   "import <file>...",
+		Short: "import chapter recordings and verse timestamps from JSON timing files",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			if err := godotenv.Load(".env"); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoad")
+				return
+			}
+			if err := helper.InitHelper(); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrInitHelper")
+				return
+			}
+			service, err := router.MakeHandler(ctx)
+			if err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrMakeHandler")
+				return
+			}
+			if err := service.ScriptureUseCase.LoadBookNames(ctx); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoadBookNames")
+				return
+			}
+			for _, path := range args {
+				if err := importAudio(ctx, service, path, audioTranslation); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrImportAudio")
+					return
+				}
+			}
+		},
+	}
+	cmdAudioImport.Flags().StringVarP(&audioTranslation, "translation", "t", "", "translation code (default the one named in the file)")
+	cmdAudio.AddCommand(cmdAudioImport)
+	cmdConcordance := &cobra.Command{
+		Use:   "concordance",
+		Short: "manage the word concordances of translations",
+	}
+	cmdConcordance.AddCommand(&cobra.Command{
+		Use:   "build [translation]...",
+		Short: "rebuild the concordance of the translations (default the stale ones)",
+		Run: func(_ *cobra.Command, args []string) {
+			if err := godotenv.Load(".env"); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoad")
+				return
+			}
+			if err := helper.InitHelper(); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrInitHelper")
+				return
+			}
+			service, err := router.MakeHandler(ctx)
+			if err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrMakeHandler")
+				return
+			}
+			if len(args) == 0 {
+				buildConcordances(ctx, service)
+				return
+			}
+			for _, translation := range args {
+				report, err := service.ConcordanceUseCase.Build(ctx, translation)
+				if err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrBuild")
+					return
+				}
+				logConcordance(ctx, report)
+			}
+		},
+	})
+	var userPassword string
+	cmdUser := &cobra.Command{
+		Use:   "user",
+		Short: "manage local accounts of the token endpoint",
+	}
+	cmdUserCreate := &cobra.Command{
+		Use:   "create <username>",
+		Short: "create an account, reading the password from stdin unless --password is set",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			if err := godotenv.Load(".env"); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoad")
+				return
+			}
+			if err := helper.InitHelper(); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrInitHelper")
+				return
+			}
+			if userPassword == "" {
+				fmt.Fprint(os.Stderr, "Password: ")
+				line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+				if err != nil && !errors.Is(err, io.EOF) {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrReadString")
+					return
+				}
+				userPassword = strings.TrimRight(line, "\r\n")
+			}
+			service, err := router.MakeHandler(ctx)
+			if err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrMakeHandler")
+				return
+			}
+			user, err := service.AuthUseCase.CreateUser(ctx, args[0], userPassword)
+			if err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCreateUser")
+				return
+			}
+			helper.Log(ctx, zap.InfoLevel, fmt.Sprintf("creating user %s successfully, token subject %s", user.Username, user.PublicID), ctxt, "")
+		},
+	}
+	cmdUserCreate.Flags().StringVar(&userPassword, "password", "", "account password (default read from stdin)")
+	cmdUser.AddCommand(cmdUserCreate)
 	rootCmd := &cobra.Command{Use: config.AppName}
 	rootCmd.AddCommand(
 		cmdVersion,
 		cmdRun,
 		cmdMigration,
+		cmdImport,
+		cmdExport,
+		cmdPlan,
+		cmdLexicon,
+		cmdCrossRef,
+		cmdAudio,
+		cmdConcordance,
+		cmdUser,
 	)
 	rootCmd.SuggestionsMinimumDistance = 1
 	if err := rootCmd.Execute(); err != nil {
 		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExecute")
 	}
 }
+
+func importTranslation(ctx context.Context, source reader.Reader, request *importerModel.Request) {
+	ctxt := "Main-importTranslation"
+	if err := godotenv.Load(".env"); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoad")
+		return
+	}
+	if err := helper.InitHelper(); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrInitHelper")
+		return
+	}
+	service, err := router.MakeHandler(ctx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrMakeHandler")
+		return
+	}
+	if err := service.ScriptureUseCase.LoadBookNames(ctx); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoadBookNames")
+		return
+	}
+	report, err := service.ImporterUseCase.Import(ctx, source, request)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrImport")
+		return
+	}
+	for _, book := range report.Books {
+		fmt.Printf("%-8s %4d chapters %6d verses\n", book.Book, book.Chapters, book.Verses)
+	}
+	action := "importing"
+	if report.Replaced {
+		action = "replacing"
+	}
+	helper.Log(ctx, zap.InfoLevel, fmt.Sprintf("%s %s successfully: %d books, %d verses, %d Strong's tags in %s", action, report.Translation, len(report.Books), report.Verses, report.Tags, report.Duration.String()), ctxt, "")
+}
+
+func importLexicon(ctx context.Context, service *router.Service, path string) error {
+	ctxt := "Main-importLexicon"
+	file, err := os.Open(path)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrOpen")
+		return err
+	}
+	defer file.Close()
+	report, err := service.LexiconUseCase.Import(ctx, file)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrImport")
+		return err
+	}
+	helper.Log(ctx, zap.InfoLevel, fmt.Sprintf("importing lexicon %s successfully: %d Hebrew, %d Greek entries in %s", path, report.Hebrew, report.Greek, report.Duration.String()), ctxt, "")
+	return nil
+}
+
+func importAudio(ctx context.Context, service *router.Service, path, translationCode string) error {
+	ctxt := "Main-importAudio"
+	file, err := os.Open(path)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrOpen")
+		return err
+	}
+	defer file.Close()
+	report, err := service.AudioUseCase.Import(ctx, file, translationCode)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrImport")
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	helper.Log(ctx, zap.InfoLevel, fmt.Sprintf("importing audio %s for %s successfully: %d chapters, %d verses in %s", path, report.Translation, report.Chapters, report.Verses, report.Duration.String()), ctxt, "")
+	return nil
+}
+
+func buildConcordances(ctx context.Context, service *router.Service) {
+	ctxt := "Main-buildConcordances"
+	reports, err := service.ConcordanceUseCase.BuildStale(ctx)
+	for _, report := range reports {
+		logConcordance(ctx, report)
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrBuildStale")
+	}
+}
+
+func logConcordance(ctx context.Context, report *concordanceModel.BuildReport) {
+	ctxt := "Main-logConcordance"
+	helper.Log(ctx, zap.InfoLevel, fmt.Sprintf("building concordance of %s successfully: %d verses, %d words, %d occurrences in %s", report.Translation, report.Verses, report.Words, report.Occurrences, report.Duration.String()), ctxt, "")
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/roysitumorang/bible/helper"
+)
+
+const (
+	claimsKey = "claims"
+)
+
+// BearerAuth rejects requests without a valid "Authorization: Bearer"
+// token and keeps the verified claims for the handlers
+func BearerAuth() func(c *fiber.Ctx) error {
+	return func(c *fiber.Ctx) error {
+		tokenString, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || tokenString == "" {
+			return helper.NewResponse(fiber.StatusUnauthorized, "Unauthorized", nil).WriteResponse(c)
+		}
+		claims, err := BearerVerify(tokenString)
+		if err != nil {
+			return helper.NewResponse(fiber.StatusUnauthorized, err.Error(), nil).WriteResponse(c)
+		}
+		// handlers key user data by the subject, which must not be shared
+		if claims.Subject == "" {
+			return helper.NewResponse(fiber.StatusUnauthorized, "token has no subject", nil).WriteResponse(c)
+		}
+		c.Locals(claimsKey, claims)
+		return c.Next()
+	}
+}
+
+// Subject returns the "sub" claim of the token BearerAuth verified
+func Subject(c *fiber.Ctx) string {
+	claims, ok := c.Locals(claimsKey).(*jwt.RegisteredClaims)
+	if !ok {
+		return ""
+	}
+	return claims.Subject
+}
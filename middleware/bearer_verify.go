@@ -3,6 +3,7 @@ package middleware
 import (
 	"errors"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -31,7 +32,7 @@ func BearerVerify(tokenString string) (*jwt.RegisteredClaims, error) {
 	if claims.Issuer != os.Getenv("JWT_ISSUER") {
 		return nil, errors.New("iss is invalid")
 	}
-	if len(claims.Audience) == 0 || claims.Audience[0] != os.Getenv("GOOGLE_API_CLIENT_ID") {
+	if !validAudience(claims.Audience) {
 		return nil, errors.New("aud is invalid")
 	}
 	if claims.ExpiresAt.Before(time.Now()) {
@@ -39,3 +40,14 @@ func BearerVerify(tokenString string) (*jwt.RegisteredClaims, error) {
 	}
 	return claims, nil
 }
+
+// validAudience accepts the tokens of the external identity provider as
+// well as the ones minted by the token endpoint
+func validAudience(audience jwt.ClaimStrings) bool {
+	for _, expected := range []string{os.Getenv("GOOGLE_API_CLIENT_ID"), os.Getenv("JWT_AUDIENCE")} {
+		if expected != "" && slices.Contains(audience, expected) {
+			return true
+		}
+	}
+	return false
+}
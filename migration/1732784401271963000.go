@@ -0,0 +1,44 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+func init() {
+	Migrations[1732784401271963000] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1732784401271963000"
+			if _, err = tx.Exec(
+				ctx,
+				`CREATE TABLE translations (
+					"id" integer NOT NULL GENERATED ALWAYS AS IDENTITY PRIMARY KEY
+					, "code" character varying(16) NOT NULL
+					, "name" character varying NOT NULL
+					, "language" character varying(8) NOT NULL
+					, "description" text
+					, "license" text
+					, "created_at" timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+					, "updated_at" timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+					, CONSTRAINT "translations_code_key" UNIQUE ("code")
+				)`,
+			); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+				return
+			}
+			if _, err = tx.Exec(ctx, `CREATE INDEX ON translations ("language")`); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1732784401271963000-Down"
+			if _, err = tx.Exec(ctx, `DROP TABLE translations`); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+	}
+}
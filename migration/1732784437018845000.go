@@ -0,0 +1,115 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+func init() {
+	Migrations[1732784437018845000] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1732784437018845000"
+			if _, err = tx.Exec(
+				ctx,
+				`CREATE TABLE books (
+					"id" smallint NOT NULL PRIMARY KEY
+					, "code" character varying(8) NOT NULL
+					, "usfm_code" character(3) NOT NULL
+					, "name" character varying NOT NULL
+					, "testament" character(2) NOT NULL
+					, "chapters_count" smallint NOT NULL
+					, CONSTRAINT "books_code_key" UNIQUE ("code")
+					, CONSTRAINT "books_usfm_code_key" UNIQUE ("usfm_code")
+					, CONSTRAINT "books_testament_check" CHECK ("testament" IN ('OT', 'NT'))
+					, CONSTRAINT "books_chapters_count_check" CHECK ("chapters_count" > 0)
+				)`,
+			); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+				return
+			}
+			// "id" doubles as the canonical (Protestant) book order
+			if _, err = tx.Exec(
+				ctx,
+				`INSERT INTO books ("id", "code", "usfm_code", "name", "testament", "chapters_count") VALUES
+				(1, 'Gen', 'GEN', 'Genesis', 'OT', 50)
+				, (2, 'Exod', 'EXO', 'Exodus', 'OT', 40)
+				, (3, 'Lev', 'LEV', 'Leviticus', 'OT', 27)
+				, (4, 'Num', 'NUM', 'Numbers', 'OT', 36)
+				, (5, 'Deut', 'DEU', 'Deuteronomy', 'OT', 34)
+				, (6, 'Josh', 'JOS', 'Joshua', 'OT', 24)
+				, (7, 'Judg', 'JDG', 'Judges', 'OT', 21)
+				, (8, 'Ruth', 'RUT', 'Ruth', 'OT', 4)
+				, (9, '1Sam', '1SA', '1 Samuel', 'OT', 31)
+				, (10, '2Sam', '2SA', '2 Samuel', 'OT', 24)
+				, (11, '1Kgs', '1KI', '1 Kings', 'OT', 22)
+				, (12, '2Kgs', '2KI', '2 Kings', 'OT', 25)
+				, (13, '1Chr', '1CH', '1 Chronicles', 'OT', 29)
+				, (14, '2Chr', '2CH', '2 Chronicles', 'OT', 36)
+				, (15, 'Ezra', 'EZR', 'Ezra', 'OT', 10)
+				, (16, 'Neh', 'NEH', 'Nehemiah', 'OT', 13)
+				, (17, 'Esth', 'EST', 'Esther', 'OT', 10)
+				, (18, 'Job', 'JOB', 'Job', 'OT', 42)
+				, (19, 'Ps', 'PSA', 'Psalms', 'OT', 150)
+				, (20, 'Prov', 'PRO', 'Proverbs', 'OT', 31)
+				, (21, 'Eccl', 'ECC', 'Ecclesiastes', 'OT', 12)
+				, (22, 'Song', 'SNG', 'Song of Songs', 'OT', 8)
+				, (23, 'Isa', 'ISA', 'Isaiah', 'OT', 66)
+				, (24, 'Jer', 'JER', 'Jeremiah', 'OT', 52)
+				, (25, 'Lam', 'LAM', 'Lamentations', 'OT', 5)
+				, (26, 'Ezek', 'EZK', 'Ezekiel', 'OT', 48)
+				, (27, 'Dan', 'DAN', 'Daniel', 'OT', 12)
+				, (28, 'Hos', 'HOS', 'Hosea', 'OT', 14)
+				, (29, 'Joel', 'JOL', 'Joel', 'OT', 3)
+				, (30, 'Amos', 'AMO', 'Amos', 'OT', 9)
+				, (31, 'Obad', 'OBA', 'Obadiah', 'OT', 1)
+				, (32, 'Jonah', 'JON', 'Jonah', 'OT', 4)
+				, (33, 'Mic', 'MIC', 'Micah', 'OT', 7)
+				, (34, 'Nah', 'NAM', 'Nahum', 'OT', 3)
+				, (35, 'Hab', 'HAB', 'Habakkuk', 'OT', 3)
+				, (36, 'Zeph', 'ZEP', 'Zephaniah', 'OT', 3)
+				, (37, 'Hag', 'HAG', 'Haggai', 'OT', 2)
+				, (38, 'Zech', 'ZEC', 'Zechariah', 'OT', 14)
+				, (39, 'Mal', 'MAL', 'Malachi', 'OT', 4)
+				, (40, 'Matt', 'MAT', 'Matthew', 'NT', 28)
+				, (41, 'Mark', 'MRK', 'Mark', 'NT', 16)
+				, (42, 'Luke', 'LUK', 'Luke', 'NT', 24)
+				, (43, 'John', 'JHN', 'John', 'NT', 21)
+				, (44, 'Acts', 'ACT', 'Acts', 'NT', 28)
+				, (45, 'Rom', 'ROM', 'Romans', 'NT', 16)
+				, (46, '1Cor', '1CO', '1 Corinthians', 'NT', 16)
+				, (47, '2Cor', '2CO', '2 Corinthians', 'NT', 13)
+				, (48, 'Gal', 'GAL', 'Galatians', 'NT', 6)
+				, (49, 'Eph', 'EPH', 'Ephesians', 'NT', 6)
+				, (50, 'Phil', 'PHP', 'Philippians', 'NT', 4)
+				, (51, 'Col', 'COL', 'Colossians', 'NT', 4)
+				, (52, '1Thess', '1TH', '1 Thessalonians', 'NT', 5)
+				, (53, '2Thess', '2TH', '2 Thessalonians', 'NT', 3)
+				, (54, '1Tim', '1TI', '1 Timothy', 'NT', 6)
+				, (55, '2Tim', '2TI', '2 Timothy', 'NT', 4)
+				, (56, 'Titus', 'TIT', 'Titus', 'NT', 3)
+				, (57, 'Phlm', 'PHM', 'Philemon', 'NT', 1)
+				, (58, 'Heb', 'HEB', 'Hebrews', 'NT', 13)
+				, (59, 'Jas', 'JAS', 'James', 'NT', 5)
+				, (60, '1Pet', '1PE', '1 Peter', 'NT', 5)
+				, (61, '2Pet', '2PE', '2 Peter', 'NT', 3)
+				, (62, '1John', '1JN', '1 John', 'NT', 5)
+				, (63, '2John', '2JN', '2 John', 'NT', 1)
+				, (64, '3John', '3JN', '3 John', 'NT', 1)
+				, (65, 'Jude', 'JUD', 'Jude', 'NT', 1)
+				, (66, 'Rev', 'REV', 'Revelation', 'NT', 22)`,
+			); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1732784437018845000-Down"
+			if _, err = tx.Exec(ctx, `DROP TABLE books`); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+	}
+}
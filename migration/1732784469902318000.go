@@ -0,0 +1,46 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+func init() {
+	Migrations[1732784469902318000] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1732784469902318000"
+			if _, err = tx.Exec(
+				ctx,
+				`CREATE TABLE chapters (
+					"id" integer NOT NULL GENERATED ALWAYS AS IDENTITY PRIMARY KEY
+					, "book_id" smallint NOT NULL REFERENCES books ("id") ON UPDATE CASCADE ON DELETE CASCADE
+					, "number" smallint NOT NULL
+					, CONSTRAINT "chapters_book_id_number_key" UNIQUE ("book_id", "number")
+					, CONSTRAINT "chapters_number_check" CHECK ("number" > 0)
+				)`,
+			); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+				return
+			}
+			if _, err = tx.Exec(
+				ctx,
+				`INSERT INTO chapters ("book_id", "number")
+				SELECT "id", generate_series(1, "chapters_count")
+				FROM books
+				ORDER BY "id"`,
+			); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1732784469902318000-Down"
+			if _, err = tx.Exec(ctx, `DROP TABLE chapters`); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+func init() {
+	Migrations[1732784502664107000] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1732784502664107000"
+			// "ordinal" sorts verses in canonical order across books and chapters,
+			// so any passage can be fetched with a single range condition
+			if _, err = tx.Exec(
+				ctx,
+				`CREATE TABLE verses (
+					"id" bigint NOT NULL GENERATED ALWAYS AS IDENTITY PRIMARY KEY
+					, "translation_id" integer NOT NULL REFERENCES translations ("id") ON UPDATE CASCADE ON DELETE CASCADE
+					, "chapter_id" integer NOT NULL REFERENCES chapters ("id") ON UPDATE CASCADE ON DELETE CASCADE
+					, "book_id" smallint NOT NULL REFERENCES books ("id") ON UPDATE CASCADE ON DELETE CASCADE
+					, "chapter" smallint NOT NULL
+					, "verse" smallint NOT NULL
+					, "ordinal" integer NOT NULL GENERATED ALWAYS AS ("book_id" * 1000000 + "chapter" * 1000 + "verse") STORED
+					, "text" text NOT NULL
+					, CONSTRAINT "verses_translation_id_book_id_chapter_verse_key" UNIQUE ("translation_id", "book_id", "chapter", "verse")
+					, CONSTRAINT "verses_verse_check" CHECK ("verse" > 0)
+				)`,
+			); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1732784502664107000-Down"
+			if _, err = tx.Exec(ctx, `DROP TABLE verses`); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+func init() {
+	Migrations[1732784533481752000] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1732784533481752000"
+			for _, query := range []string{
+				`CREATE UNIQUE INDEX ON verses ("translation_id", "ordinal")`,
+				`CREATE INDEX ON verses ("chapter_id")`,
+				`CREATE INDEX ON verses ("book_id", "chapter", "verse")`,
+				`CREATE INDEX ON books ("testament", "id")`,
+			} {
+				if _, err = tx.Exec(ctx, query); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+					return
+				}
+			}
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1732784533481752000-Down"
+			for _, query := range []string{
+				`DROP INDEX books_testament_id_idx`,
+				`DROP INDEX verses_book_id_chapter_verse_idx`,
+				`DROP INDEX verses_chapter_id_idx`,
+				`DROP INDEX verses_translation_id_ordinal_idx`,
+			} {
+				if _, err = tx.Exec(ctx, query); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+					return
+				}
+			}
+			return
+		},
+	}
+}
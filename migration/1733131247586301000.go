@@ -0,0 +1,41 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+func init() {
+	Migrations[1733131247586301000] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1733131247586301000"
+			// localized names on top of the ones embedded in the canon package,
+			// for languages added without a new build
+			if _, err = tx.Exec(
+				ctx,
+				`CREATE TABLE book_names (
+					"book_id" smallint NOT NULL REFERENCES books ("id") ON UPDATE CASCADE ON DELETE CASCADE
+					, "language" character varying(8) NOT NULL
+					, "name" character varying NOT NULL
+					, "abbreviation" character varying NOT NULL
+					, "aliases" character varying[] NOT NULL DEFAULT '{}'
+					, "created_at" timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+					, "updated_at" timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+					, PRIMARY KEY ("book_id", "language")
+				)`,
+			); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1733131247586301000-Down"
+			if _, err = tx.Exec(ctx, `DROP TABLE book_names`); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+	}
+}
@@ -0,0 +1,29 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+func init() {
+	Migrations[1733560964823107000] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1733560964823107000"
+			// paragraph, poetry, heading and note structure of the verse as
+			// models.Segment list; "text" keeps the flattened text
+			if _, err = tx.Exec(ctx, `ALTER TABLE verses ADD COLUMN "content" jsonb`); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1733560964823107000-Down"
+			if _, err = tx.Exec(ctx, `ALTER TABLE verses DROP COLUMN "content"`); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+func init() {
+	Migrations[1734077385021456000] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1734077385021456000"
+			for _, query := range []string{
+				`ALTER TABLE translations ADD COLUMN "text_search_config" regconfig NOT NULL DEFAULT 'simple'`,
+				// the indonesian snowball stemmer ships with PostgreSQL 13+,
+				// older servers keep the 'simple' fallback
+				`UPDATE translations SET "text_search_config" = COALESCE(
+					(
+						SELECT c."oid"::regconfig
+						FROM pg_ts_config c
+						WHERE c."cfgname" = CASE "language"
+							WHEN 'en' THEN 'english'
+							WHEN 'eng' THEN 'english'
+							WHEN 'id' THEN 'indonesian'
+							WHEN 'ind' THEN 'indonesian'
+						END
+					),
+					'simple'
+				)`,
+				`ALTER TABLE verses ADD COLUMN "search_vector" tsvector`,
+				`CREATE FUNCTION verses_search_vector() RETURNS trigger AS $$
+				BEGIN
+					NEW."search_vector" := to_tsvector(
+						COALESCE(
+							(SELECT "text_search_config" FROM translations WHERE "id" = NEW."translation_id"),
+							'simple'
+						),
+						NEW."text"
+					);
+					RETURN NEW;
+				END
+				$$ LANGUAGE plpgsql`,
+				`CREATE TRIGGER verses_search_vector
+				BEFORE INSERT OR UPDATE OF "text", "translation_id" ON verses
+				FOR EACH ROW EXECUTE FUNCTION verses_search_vector()`,
+				`CREATE FUNCTION translations_search_vector() RETURNS trigger AS $$
+				BEGIN
+					UPDATE verses SET "search_vector" = to_tsvector(NEW."text_search_config", "text")
+					WHERE "translation_id" = NEW."id";
+					RETURN NEW;
+				END
+				$$ LANGUAGE plpgsql`,
+				`CREATE TRIGGER translations_search_vector
+				AFTER UPDATE OF "text_search_config" ON translations
+				FOR EACH ROW
+				WHEN (OLD."text_search_config" IS DISTINCT FROM NEW."text_search_config")
+				EXECUTE FUNCTION translations_search_vector()`,
+				`UPDATE verses v SET "search_vector" = to_tsvector(t."text_search_config", v."text")
+				FROM translations t
+				WHERE t."id" = v."translation_id"`,
+				`CREATE INDEX ON verses USING GIN ("search_vector")`,
+			} {
+				if _, err = tx.Exec(ctx, query); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+					return
+				}
+			}
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1734077385021456000-Down"
+			for _, query := range []string{
+				`DROP TRIGGER translations_search_vector ON translations`,
+				`DROP FUNCTION translations_search_vector()`,
+				`DROP TRIGGER verses_search_vector ON verses`,
+				`DROP FUNCTION verses_search_vector()`,
+				`ALTER TABLE verses DROP COLUMN "search_vector"`,
+				`ALTER TABLE translations DROP COLUMN "text_search_config"`,
+			} {
+				if _, err = tx.Exec(ctx, query); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+					return
+				}
+			}
+			return
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+func init() {
+	Migrations[1734424213745329000] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1734424213745329000"
+			// code of a versification.Registry scheme; the rules themselves
+			// are embedded data files, kjv being the standard numbering
+			if _, err = tx.Exec(
+				ctx,
+				`ALTER TABLE translations ADD COLUMN "versification" character varying(16) NOT NULL DEFAULT 'kjv'`,
+			); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1734424213745329000-Down"
+			if _, err = tx.Exec(ctx, `ALTER TABLE translations DROP COLUMN "versification"`); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+func init() {
+	Migrations[1734510907123884000] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1734510907123884000"
+			// one pick per language per day, "date" being the calendar day
+			// in TIME_ZONE; "reference" is OSIS, e.g. John.3.16
+			if _, err = tx.Exec(
+				ctx,
+				`CREATE TABLE verses_of_the_day (
+					"language" character varying(8) NOT NULL
+					, "date" date NOT NULL
+					, "reference" character varying(64) NOT NULL
+					, "versification" character varying(16) NOT NULL
+					, "created_at" timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+					, PRIMARY KEY ("language", "date")
+				)`,
+			); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1734510907123884000-Down"
+			if _, err = tx.Exec(ctx, `DROP TABLE verses_of_the_day`); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+	}
+}
@@ -0,0 +1,66 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+func init() {
+	Migrations[1734597512884061000] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1734597512884061000"
+			for _, query := range []string{
+				`CREATE TABLE reading_plans (
+					"id" bigint NOT NULL GENERATED ALWAYS AS IDENTITY PRIMARY KEY
+					, "code" character varying(32) NOT NULL
+					, "name" character varying NOT NULL
+					, "description" text
+					, "days_count" smallint NOT NULL
+					, "created_at" timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+					, "updated_at" timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+					, CONSTRAINT "reading_plans_code_key" UNIQUE ("code")
+				)`,
+				`CREATE TABLE reading_plan_days (
+					"id" bigint NOT NULL GENERATED ALWAYS AS IDENTITY PRIMARY KEY
+					, "plan_id" bigint NOT NULL REFERENCES reading_plans ("id") ON DELETE CASCADE
+					, "day" smallint NOT NULL
+					, CONSTRAINT "reading_plan_days_plan_id_day_key" UNIQUE ("plan_id", "day")
+					, CONSTRAINT "reading_plan_days_day_check" CHECK ("day" > 0)
+				)`,
+				// "reference" is OSIS, numbered in the standard versification
+				`CREATE TABLE reading_plan_passages (
+					"id" bigint NOT NULL GENERATED ALWAYS AS IDENTITY PRIMARY KEY
+					, "day_id" bigint NOT NULL REFERENCES reading_plan_days ("id") ON DELETE CASCADE
+					, "position" smallint NOT NULL
+					, "reference" character varying(64) NOT NULL
+					, CONSTRAINT "reading_plan_passages_day_id_position_key" UNIQUE ("day_id", "position")
+				)`,
+				// "user_id" is the JWT subject
+				`CREATE TABLE reading_plan_enrollments (
+					"id" bigint NOT NULL GENERATED ALWAYS AS IDENTITY PRIMARY KEY
+					, "plan_id" bigint NOT NULL REFERENCES reading_plans ("id") ON DELETE CASCADE
+					, "user_id" character varying(255) NOT NULL
+					, "start_date" date NOT NULL
+					, "created_at" timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+					, "updated_at" timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+					, CONSTRAINT "reading_plan_enrollments_user_id_plan_id_key" UNIQUE ("user_id", "plan_id")
+				)`,
+			} {
+				if _, err = tx.Exec(ctx, query); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+					return
+				}
+			}
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1734597512884061000-Down"
+			if _, err = tx.Exec(ctx, `DROP TABLE reading_plan_enrollments, reading_plan_passages, reading_plan_days, reading_plans`); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+func init() {
+	Migrations[1734683276915028000] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1734683276915028000"
+			for _, query := range []string{
+				// "id" is a snowflake ID and "public_id" its sqids encoding;
+				// "user_id" is the JWT subject and "reference" OSIS, the
+				// ordinals bounding the range like verses."ordinal"
+				`CREATE TABLE annotations (
+					"id" bigint NOT NULL PRIMARY KEY
+					, "public_id" character varying NOT NULL
+					, "user_id" character varying(255) NOT NULL
+					, "type" character varying(16) NOT NULL
+					, "reference" character varying(64) NOT NULL
+					, "start_ordinal" integer NOT NULL
+					, "end_ordinal" integer NOT NULL
+					, "color" character varying(16)
+					, "label" character varying(100)
+					, "body" text
+					, "created_at" timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+					, "updated_at" timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+					, CONSTRAINT "annotations_public_id_key" UNIQUE ("public_id")
+					, CONSTRAINT "annotations_type_check" CHECK ("type" IN ('bookmark', 'highlight', 'note'))
+					, CONSTRAINT "annotations_ordinal_check" CHECK ("start_ordinal" <= "end_ordinal")
+				)`,
+				`CREATE INDEX ON annotations ("user_id", "type", "start_ordinal")`,
+			} {
+				if _, err = tx.Exec(ctx, query); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+					return
+				}
+			}
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1734683276915028000-Down"
+			if _, err = tx.Exec(ctx, `DROP TABLE annotations`); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+func init() {
+	Migrations[1734771094327615000] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1734771094327615000"
+			// accounts of the built-in token issuer; "id" is a snowflake ID and
+			// "public_id", its sqids encoding, the JWT subject
+			if _, err = tx.Exec(
+				ctx,
+				`CREATE TABLE users (
+					"id" bigint NOT NULL PRIMARY KEY
+					, "public_id" character varying NOT NULL
+					, "username" character varying(64) NOT NULL
+					, "password_hash" character varying NOT NULL
+					, "created_at" timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+					, "updated_at" timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+					, CONSTRAINT "users_public_id_key" UNIQUE ("public_id")
+					, CONSTRAINT "users_username_key" UNIQUE ("username")
+				)`,
+			); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1734771094327615000-Down"
+			if _, err = tx.Exec(ctx, `DROP TABLE users`); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+	}
+}
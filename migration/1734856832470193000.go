@@ -0,0 +1,65 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+func init() {
+	Migrations[1734856832470193000] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1734856832470193000"
+			for _, query := range []string{
+				// "strong" is normalized, e.g. H7225 or G3588, and keys the
+				// public domain Strong's Hebrew and Greek dictionaries
+				`CREATE TABLE lexicon_entries (
+					"strong" character varying(8) NOT NULL PRIMARY KEY
+					, "language" character varying(8) NOT NULL
+					, "lemma" text NOT NULL
+					, "transliteration" text
+					, "pronunciation" text
+					, "derivation" text
+					, "definition" text
+					, "kjv_usage" text
+					, "created_at" timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+					, "updated_at" timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+					, CONSTRAINT "lexicon_entries_language_check" CHECK ("language" IN ('hbo', 'grc'))
+				)`,
+				// one row per Strong's number of a tagged word; "position"
+				// numbers the tagged words of the verse, and verses being
+				// replaced on reimport take their words along
+				`CREATE TABLE verse_words (
+					"translation_id" integer NOT NULL
+					, "ordinal" integer NOT NULL
+					, "position" smallint NOT NULL
+					, "word" text NOT NULL
+					, "strong" character varying(8) NOT NULL
+					, PRIMARY KEY ("translation_id", "ordinal", "position", "strong")
+					, FOREIGN KEY ("translation_id", "ordinal") REFERENCES verses ("translation_id", "ordinal") ON UPDATE CASCADE ON DELETE CASCADE
+				)`,
+				`CREATE INDEX ON verse_words ("strong", "translation_id", "ordinal")`,
+			} {
+				if _, err = tx.Exec(ctx, query); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+					return
+				}
+			}
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1734856832470193000-Down"
+			for _, query := range []string{
+				`DROP TABLE verse_words`,
+				`DROP TABLE lexicon_entries`,
+			} {
+				if _, err = tx.Exec(ctx, query); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+					return
+				}
+			}
+			return
+		},
+	}
+}
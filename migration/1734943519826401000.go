@@ -0,0 +1,45 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+func init() {
+	Migrations[1734943519826401000] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1734943519826401000"
+			for _, query := range []string{
+				// ordinals like verses."ordinal", numbered in the standard
+				// (KJV) versification; "to_reference" is the OSIS form of the
+				// target range and "votes" its relevance, negative when users
+				// of the dataset found it unhelpful
+				`CREATE TABLE cross_references (
+					"id" bigint NOT NULL GENERATED ALWAYS AS IDENTITY PRIMARY KEY
+					, "from_ordinal" integer NOT NULL
+					, "to_start_ordinal" integer NOT NULL
+					, "to_end_ordinal" integer NOT NULL
+					, "to_reference" character varying(64) NOT NULL
+					, "votes" integer NOT NULL DEFAULT 0
+					, CONSTRAINT "cross_references_from_ordinal_to_start_ordinal_to_end_ordinal_key" UNIQUE ("from_ordinal", "to_start_ordinal", "to_end_ordinal")
+					, CONSTRAINT "cross_references_ordinal_check" CHECK ("to_start_ordinal" <= "to_end_ordinal")
+				)`,
+			} {
+				if _, err = tx.Exec(ctx, query); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+					return
+				}
+			}
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1734943519826401000-Down"
+			if _, err = tx.Exec(ctx, `DROP TABLE cross_references`); err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			}
+			return
+		},
+	}
+}
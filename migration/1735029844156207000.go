@@ -0,0 +1,60 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+func init() {
+	Migrations[1735029844156207000] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1735029844156207000"
+			for _, query := range []string{
+				// one recording per translation chapter; "locator" is the URL
+				// or storage key the player fetches, times are milliseconds
+				`CREATE TABLE audio_recordings (
+					"id" bigint NOT NULL GENERATED ALWAYS AS IDENTITY PRIMARY KEY
+					, "translation_id" integer NOT NULL REFERENCES translations ("id") ON UPDATE CASCADE ON DELETE CASCADE
+					, "book_id" smallint NOT NULL REFERENCES books ("id") ON UPDATE CASCADE ON DELETE CASCADE
+					, "chapter" smallint NOT NULL
+					, "locator" text NOT NULL
+					, "duration_ms" integer NOT NULL
+					, "narrator" character varying(255)
+					, "created_at" timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+					, "updated_at" timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+					, CONSTRAINT "audio_recordings_translation_id_book_id_chapter_key" UNIQUE ("translation_id", "book_id", "chapter")
+					, CONSTRAINT "audio_recordings_duration_ms_check" CHECK ("duration_ms" > 0)
+				)`,
+				`CREATE TABLE audio_timestamps (
+					"recording_id" bigint NOT NULL REFERENCES audio_recordings ("id") ON UPDATE CASCADE ON DELETE CASCADE
+					, "verse" smallint NOT NULL
+					, "start_ms" integer NOT NULL
+					, "end_ms" integer NOT NULL
+					, PRIMARY KEY ("recording_id", "verse")
+					, CONSTRAINT "audio_timestamps_check" CHECK (0 <= "start_ms" AND "start_ms" < "end_ms")
+				)`,
+			} {
+				if _, err = tx.Exec(ctx, query); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+					return
+				}
+			}
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1735029844156207000-Down"
+			for _, query := range []string{
+				`DROP TABLE audio_timestamps`,
+				`DROP TABLE audio_recordings`,
+			} {
+				if _, err = tx.Exec(ctx, query); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+					return
+				}
+			}
+			return
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+func init() {
+	Migrations[1735116155694338000] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1735116155694338000"
+			for _, query := range []string{
+				// the concordance is stale until "concordance_built_at" catches
+				// up with the "updated_at" an import leaves behind
+				`ALTER TABLE translations ADD COLUMN "concordance_built_at" timestamp with time zone`,
+				// one row per word form and book: "ordinals" lists the verses
+				// the form occurs in, "occurrences" counts repeats within them
+				`CREATE TABLE concordance_words (
+					"translation_id" integer NOT NULL REFERENCES translations ("id") ON UPDATE CASCADE ON DELETE CASCADE
+					, "word" character varying NOT NULL
+					, "book_id" smallint NOT NULL REFERENCES books ("id") ON UPDATE CASCADE ON DELETE CASCADE
+					, "occurrences" integer NOT NULL
+					, "ordinals" integer[] NOT NULL
+					, PRIMARY KEY ("translation_id", "word", "book_id")
+					, CONSTRAINT "concordance_words_occurrences_check" CHECK ("occurrences" > 0)
+				)`,
+				`CREATE INDEX ON concordance_words ("translation_id", "book_id", "occurrences" DESC)`,
+			} {
+				if _, err = tx.Exec(ctx, query); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+					return
+				}
+			}
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1735116155694338000-Down"
+			for _, query := range []string{
+				`DROP TABLE concordance_words`,
+				`ALTER TABLE translations DROP COLUMN "concordance_built_at"`,
+			} {
+				if _, err = tx.Exec(ctx, query); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+					return
+				}
+			}
+			return
+		},
+	}
+}
@@ -0,0 +1,45 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+func init() {
+	Migrations[1735202731408562000] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1735202731408562000"
+			// "ordinal" packs chapter and verse into three digits each, a
+			// larger number would spill into the next chapter or book
+			for _, query := range []string{
+				`ALTER TABLE verses DROP CONSTRAINT "verses_verse_check"`,
+				`ALTER TABLE verses
+					ADD CONSTRAINT "verses_chapter_check" CHECK ("chapter" BETWEEN 1 AND 999)
+					, ADD CONSTRAINT "verses_verse_check" CHECK ("verse" BETWEEN 1 AND 999)`,
+			} {
+				if _, err = tx.Exec(ctx, query); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+					return
+				}
+			}
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-1735202731408562000-Down"
+			for _, query := range []string{
+				`ALTER TABLE verses
+					DROP CONSTRAINT "verses_chapter_check"
+					, DROP CONSTRAINT "verses_verse_check"`,
+				`ALTER TABLE verses ADD CONSTRAINT "verses_verse_check" CHECK ("verse" > 0)`,
+			} {
+				if _, err = tx.Exec(ctx, query); err != nil {
+					helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+					return
+				}
+			}
+			return
+		},
+	}
+}
@@ -4,137 +4,429 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"os"
+	"slices"
 	"sort"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/roysitumorang/bible/helper"
 	"go.uber.org/zap"
 )
 
 type (
 	Migration struct {
-		tx pgx.Tx
+		db     *pgxpool.Pool
+		config *Config
 	}
+
+	Config struct {
+		// how long to wait for another instance to finish migrating
+		LockTimeout time.Duration
+	}
+
+	// DB runs the statements of a migration: its own transaction, or the
+	// connection itself for a migration that opts out of one
+	DB interface {
+		Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+		Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+		QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	}
+
+	// Step is a registered migration: Up applies it and Down reverts it,
+	// leaving the schema as the previous version expects it. Each runs in
+	// a transaction of its own, committed along with the version, unless
+	// NoTransaction: statements such as CREATE INDEX CONCURRENTLY cannot
+	// run inside one, and a failure may leave such a step half done, so it
+	// should be safe to run again
+	Step struct {
+		Up            func(ctx context.Context, tx DB) error
+		Down          func(ctx context.Context, tx DB) error
+		NoTransaction bool
+	}
+
+	// Status is the state of a version; AppliedAt is unknown for the
+	// versions applied before it was recorded
+	Status struct {
+		Version   int64
+		State     string
+		AppliedAt *time.Time
+	}
+)
+
+const (
+	StatePending = "pending"
+	StateApplied = "applied"
+	// applied to the database but not registered in this build
+	StateMissing = "missing"
+
+	DefaultLockTimeout = time.Minute
+
+	// key of the advisory lock held while migrating, arbitrary but the
+	// same for every instance on the database
+	lockKey          = 1732784401271963000
+	lockPollInterval = 500 * time.Millisecond
 )
 
 var (
-	Migrations = map[int64]func(ctx context.Context, tx pgx.Tx) error{}
+	Migrations = map[int64]Step{}
+
+	ErrUnknownVersion = errors.New("unknown migration version")
+	ErrIrreversible   = errors.New("migration has no down step")
+	ErrLockTimeout    = errors.New("timed out waiting for another instance to finish migrating")
+	// returned by the steps CreateMigrationFile generates until they are
+	// filled in, so an empty migration is never recorded as applied
+	ErrNotImplemented = errors.New("migration not implemented")
 )
 
-func NewMigration(tx pgx.Tx) *Migration {
+func NewMigration(db *pgxpool.Pool, config *Config) *Migration {
 	return &Migration{
-		tx: tx,
+		db:     db,
+		config: config,
+	}
+}
+
+// LoadConfig reads MIGRATION_LOCK_TIMEOUT, optional
+func LoadConfig() (*Config, error) {
+	response := Config{
+		LockTimeout: DefaultLockTimeout,
+	}
+	if envTimeout, ok := os.LookupEnv("MIGRATION_LOCK_TIMEOUT"); ok && envTimeout != "" {
+		timeout, err := time.ParseDuration(envTimeout)
+		if err != nil || timeout <= 0 {
+			return nil, errors.New("env MIGRATION_LOCK_TIMEOUT requires a positive duration, e.g. 1m")
+		}
+		response.LockTimeout = timeout
 	}
+	return &response, nil
 }
 
+// Migrate applies every pending migration in version order
 func (m *Migration) Migrate(ctx context.Context) error {
 	ctxt := "Migration-Migrate"
-	var exists int
-	err := m.tx.QueryRow(
-		ctx,
-		`SELECT COUNT(1)
-		FROM information_schema.tables
-		WHERE table_name = 'migrations'`,
-	).Scan(&exists)
-	if errors.Is(err, pgx.ErrNoRows) {
-		err = nil
+	if err := m.run(ctx, func(conn *pgxpool.Conn, applied map[int64]*time.Time) error {
+		return m.up(ctx, conn, applied, math.MaxInt64)
+	}); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrRun")
+		return err
+	}
+	return nil
+}
+
+// Rollback reverts the last steps applied migrations, latest first
+func (m *Migration) Rollback(ctx context.Context, steps int) error {
+	ctxt := "Migration-Rollback"
+	if err := m.run(ctx, func(conn *pgxpool.Conn, applied map[int64]*time.Time) error {
+		versions := sortedVersions(applied)
+		slices.Reverse(versions)
+		return m.down(ctx, conn, versions[:min(steps, len(versions))])
+	}); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrRun")
+		return err
 	}
+	return nil
+}
+
+// MigrateTo leaves exactly the migrations up to version applied: the
+// later ones are reverted, latest first, and the pending earlier ones
+// applied; version 0 reverts them all
+func (m *Migration) MigrateTo(ctx context.Context, version int64) error {
+	ctxt := "Migration-MigrateTo"
+	if _, ok := Migrations[version]; !ok && version != 0 {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+	if err := m.run(ctx, func(conn *pgxpool.Conn, applied map[int64]*time.Time) error {
+		versions := sortedVersions(applied)
+		slices.Reverse(versions)
+		later := slices.IndexFunc(versions, func(applied int64) bool {
+			return applied <= version
+		})
+		if later >= 0 {
+			versions = versions[:later]
+		}
+		if err := m.down(ctx, conn, versions); err != nil {
+			return err
+		}
+		return m.up(ctx, conn, applied, version)
+	}); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrRun")
+		return err
+	}
+	return nil
+}
+
+// Status lists the registered versions, and the applied ones no longer
+// registered, in version order
+func (m *Migration) Status(ctx context.Context) ([]*Status, error) {
+	ctxt := "Migration-Status"
+	applied, err := m.applied(ctx, m.db)
 	if err != nil {
-		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
-		if errRollback := m.tx.Rollback(ctx); errRollback != nil {
-			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrRollback")
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrApplied")
+		return nil, err
+	}
+	versions := sortedVersions(Migrations)
+	for version := range applied {
+		if _, ok := Migrations[version]; !ok {
+			versions = append(versions, version)
+		}
+	}
+	slices.Sort(versions)
+	response := make([]*Status, len(versions))
+	for i, version := range versions {
+		status := Status{
+			Version: version,
+			State:   StatePending,
 		}
+		if appliedAt, ok := applied[version]; ok {
+			status.State, status.AppliedAt = StateApplied, appliedAt
+			if _, ok := Migrations[version]; !ok {
+				status.State = StateMissing
+			}
+		}
+		response[i] = &status
+	}
+	return response, nil
+}
+
+// Plan returns the versions Migrate would apply, in order, without
+// changing the database
+func (m *Migration) Plan(ctx context.Context) ([]int64, error) {
+	ctxt := "Migration-Plan"
+	applied, err := m.applied(ctx, m.db)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrApplied")
+		return nil, err
+	}
+	return pending(applied, math.MaxInt64), nil
+}
+
+// run calls fn with a connection and the applied versions, holding the
+// migration lock throughout so instances starting together migrate one at
+// a time and the later ones find the versions applied by the first
+func (m *Migration) run(ctx context.Context, fn func(conn *pgxpool.Conn, applied map[int64]*time.Time) error) error {
+	ctxt := "Migration-run"
+	conn, err := m.db.Acquire(ctx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrAcquire")
 		return err
 	}
-	if exists == 0 {
-		if _, err := m.tx.Exec(
-			ctx,
-			`CREATE TABLE migrations (
-				"version" bigint NOT NULL PRIMARY KEY
-			)`,
-		); err != nil {
+	defer conn.Release()
+	if err := m.lock(ctx, conn); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLock")
+		return err
+	}
+	defer func() {
+		if _, err := conn.Exec(ctx, `SELECT pg_advisory_unlock($1)`, int64(lockKey)); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrUnlock")
+		}
+	}()
+	for _, query := range []string{
+		`CREATE TABLE IF NOT EXISTS migrations (
+			"version" bigint NOT NULL PRIMARY KEY
+		)`,
+		// left NULL for the versions applied before it was added
+		`ALTER TABLE migrations ADD COLUMN IF NOT EXISTS "applied_at" timestamp with time zone`,
+	} {
+		if _, err := conn.Exec(ctx, query); err != nil {
 			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
-			if errRollback := m.tx.Rollback(ctx); errRollback != nil {
-				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrRollback")
-			}
 			return err
 		}
 	}
-	rows, err := m.tx.Query(ctx, `SELECT "version" FROM "migrations" ORDER BY "version"`)
+	applied, err := m.applied(ctx, conn)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrApplied")
+		return err
+	}
+	return fn(conn, applied)
+}
+
+// lock takes the session level advisory lock, released by run or when the
+// connection closes, waiting up to the configured timeout for the instance
+// holding it
+func (m *Migration) lock(ctx context.Context, conn *pgxpool.Conn) error {
+	ctxt := "Migration-lock"
+	deadline := time.Now().Add(m.config.LockTimeout)
+	for logged := false; ; logged = true {
+		var locked bool
+		if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, int64(lockKey)).Scan(&locked); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return err
+		}
+		if locked {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return ErrLockTimeout
+		}
+		if !logged {
+			helper.Log(ctx, zap.InfoLevel, "waiting for another instance to finish migrating", ctxt, "")
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
+
+// applied maps the applied versions to the time they were applied at,
+// none when the migrations table does not exist yet
+func (m *Migration) applied(ctx context.Context, db DB) (map[int64]*time.Time, error) {
+	ctxt := "Migration-applied"
+	var exists, recorded bool
+	if err := db.QueryRow(
+		ctx,
+		`SELECT to_regclass('migrations') IS NOT NULL
+			, EXISTS (
+				SELECT 1
+				FROM information_schema.columns
+				WHERE table_name = 'migrations'
+					AND column_name = 'applied_at'
+			)`,
+	).Scan(&exists, &recorded); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return nil, err
+	}
+	response := map[int64]*time.Time{}
+	if !exists {
+		return response, nil
+	}
+	// status and plan do not add "applied_at" to a table older than it
+	appliedAt := `NULL::timestamp with time zone`
+	if recorded {
+		appliedAt = `"applied_at"`
+	}
+	rows, err := db.Query(ctx, fmt.Sprintf(`SELECT "version", %s FROM migrations ORDER BY "version"`, appliedAt))
 	if errors.Is(err, pgx.ErrNoRows) {
 		err = nil
 	}
 	if err != nil {
 		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
-		if errRollback := m.tx.Rollback(ctx); errRollback != nil {
-			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrRollback")
-		}
-		return err
+		return nil, err
 	}
 	defer rows.Close()
-	mapVersions := map[int64]int{}
 	for rows.Next() {
-		var version int64
-		if err := rows.Scan(&version); err != nil {
+		var (
+			version   int64
+			appliedAt *time.Time
+		)
+		if err := rows.Scan(&version, &appliedAt); err != nil {
 			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
-			if errRollback := m.tx.Rollback(ctx); errRollback != nil {
-				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrRollback")
-			}
+			return nil, err
+		}
+		response[version] = appliedAt
+	}
+	return response, nil
+}
+
+// up applies the registered migrations not in applied, up to target
+func (m *Migration) up(ctx context.Context, conn *pgxpool.Conn, applied map[int64]*time.Time, target int64) error {
+	ctxt := "Migration-up"
+	for _, version := range pending(applied, target) {
+		step := Migrations[version]
+		if err := m.step(ctx, conn, version, "applying", step.NoTransaction, step.Up, `INSERT INTO "migrations" ("version", "applied_at") VALUES ($1, CURRENT_TIMESTAMP)`); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrStep")
 			return err
 		}
-		mapVersions[version] = 1
 	}
-	sortedVersions := make([]int64, len(Migrations))
-	var i int
-	for version := range Migrations {
-		sortedVersions[i] = version
-		i++
+	return nil
+}
+
+// down reverts versions in the order given, forgetting each of them
+func (m *Migration) down(ctx context.Context, conn *pgxpool.Conn, versions []int64) error {
+	ctxt := "Migration-down"
+	for _, version := range versions {
+		step, ok := Migrations[version]
+		if !ok {
+			return fmt.Errorf("migration function for version %d not found", version)
+		}
+		if step.Down == nil {
+			return fmt.Errorf("%w: %d", ErrIrreversible, version)
+		}
+		if err := m.step(ctx, conn, version, "reverting", step.NoTransaction, step.Down, `DELETE FROM "migrations" WHERE "version" = $1`); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrStep")
+			return err
+		}
 	}
-	if len(sortedVersions) > 0 {
-		sort.Slice(
-			sortedVersions,
-			func(i, j int) bool {
-				return sortedVersions[i] < sortedVersions[j]
-			},
-		)
+	return nil
+}
+
+// step runs fn and then record, which adds or removes version in the
+// migrations table, committing both together unless noTransaction
+func (m *Migration) step(ctx context.Context, conn *pgxpool.Conn, version int64, action string, noTransaction bool, fn func(ctx context.Context, tx DB) error, record string) error {
+	ctxt := "Migration-step"
+	now := time.Now()
+	mode := "in a transaction"
+	if noTransaction {
+		mode = "without a transaction"
 	}
-	for _, version := range sortedVersions {
-		if _, ok := mapVersions[version]; ok {
-			continue
+	helper.Log(ctx, zap.InfoLevel, fmt.Sprintf("%s migration %d %s", action, version, mode), ctxt, "")
+	if noTransaction {
+		if err := fn(ctx, conn); err != nil {
+			return fmt.Errorf("migration %d: %w", version, err)
 		}
-		function, ok := Migrations[version]
-		if !ok {
-			err := fmt.Errorf("migration function for version %d not found", version)
-			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrOK")
-			if errRollback := m.tx.Rollback(ctx); errRollback != nil {
-				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrRollback")
-			}
+		if _, err := conn.Exec(ctx, record, version); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
 			return err
 		}
-		if err := function(ctx, m.tx); err != nil {
-			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFunction")
-			if errRollback := m.tx.Rollback(ctx); errRollback != nil {
-				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrRollback")
-			}
+	} else {
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrBegin")
 			return err
 		}
-		if _, err := m.tx.Exec(ctx, `INSERT INTO "migrations" ("version") VALUES ($1)`, version); err != nil {
-			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
-			if errRollback := m.tx.Rollback(ctx); errRollback != nil {
-				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrRollback")
+		defer func() {
+			if errRollback := tx.Rollback(ctx); errRollback != nil && !errors.Is(errRollback, pgx.ErrTxClosed) {
+				helper.Capture(ctx, zap.ErrorLevel, errRollback, ctxt, "ErrRollback")
 			}
+		}()
+		if err := fn(ctx, tx); err != nil {
+			return fmt.Errorf("migration %d: %w", version, err)
+		}
+		if _, err := tx.Exec(ctx, record, version); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			return err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCommit")
 			return err
 		}
 	}
-	if err := m.tx.Commit(ctx); err != nil {
-		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCommit")
-		return err
-	}
+	helper.Log(ctx, zap.InfoLevel, fmt.Sprintf("%s migration %d done in %s", action, version, time.Since(now).String()), ctxt, "")
 	return nil
 }
 
+// pending returns the registered versions not in applied, up to target
+func pending(applied map[int64]*time.Time, target int64) []int64 {
+	var response []int64
+	for _, version := range sortedVersions(Migrations) {
+		if version > target {
+			break
+		}
+		if _, ok := applied[version]; !ok {
+			response = append(response, version)
+		}
+	}
+	return response
+}
+
+func sortedVersions[V any](versions map[int64]V) []int64 {
+	response := make([]int64, 0, len(versions))
+	for version := range versions {
+		response = append(response, version)
+	}
+	sort.Slice(
+		response,
+		func(i, j int) bool {
+			return response[i] < response[j]
+		},
+	)
+	return response
+}
+
 func (m *Migration) CreateMigrationFile(_ context.Context) error {
 	now := time.Now().UTC().UnixNano()
 	filepath := fmt.Sprintf("./migration/%d.go", now)
@@ -144,17 +436,52 @@ func (m *Migration) CreateMigrationFile(_ context.Context) error {
 import (
 	"context"
 
-	"github.com/jackc/pgx/v5"
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
 )
 
 func init() {
-	Migrations[%d] = func(ctx context.Context, tx pgx.Tx) (err error) {
-		ctxt := "Migration-%d"
-		return
+	Migrations[%d] = Step{
+		Up: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-%d"
+			err = ErrNotImplemented
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrNotImplemented")
+			return
+		},
+		Down: func(ctx context.Context, tx DB) (err error) {
+			ctxt := "Migration-%d-Down"
+			err = ErrNotImplemented
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrNotImplemented")
+			return
+		},
 	}
-}`,
+}
+`,
 		now,
 		now,
+		now,
+	)
+	return os.WriteFile(
+		filepath,
+		helper.String2ByteSlice(content),
+		0600,
+	)
+}
+
+// CreateSQLMigrationFile creates an empty .sql migration in migration/sql,
+// registered by LoadSQL once built into the binary
+func (m *Migration) CreateSQLMigrationFile(_ context.Context) error {
+	now := time.Now().UTC().UnixNano()
+	filepath := fmt.Sprintf("./migration/sql/%d.sql", now)
+	content := fmt.Sprintf(
+		`%s
+
+
+%s
+
+`,
+		sqlMarkerUp,
+		sqlMarkerDown,
 	)
 	return os.WriteFile(
 		filepath,
@@ -0,0 +1,126 @@
+package migration
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/roysitumorang/bible/helper"
+	"go.uber.org/zap"
+)
+
+const (
+	// markers starting the sections of a .sql migration, each on a line
+	// of its own; no-transaction may appear anywhere and sets NoTransaction
+	sqlMarkerUp            = "-- migrate:up"
+	sqlMarkerDown          = "-- migrate:down"
+	sqlMarkerNoTransaction = "-- migrate:no-transaction"
+)
+
+var (
+	//go:embed sql
+	sqlFiles embed.FS
+
+	// LoadSQL registers the .sql migrations embedded from migration/sql
+	// alongside the Go ones, once
+	LoadSQL = sync.OnceValue(func() error {
+		return LoadFS(sqlFiles, "sql")
+	})
+)
+
+// LoadFS registers every <version>[_name].sql migration found in dir; a
+// version already registered, by a Go migration or another file, is an
+// error
+func LoadFS(fsys fs.FS, dir string) error {
+	paths, err := fs.Glob(fsys, path.Join(dir, "*.sql"))
+	if err != nil {
+		return err
+	}
+	for _, filepath := range paths {
+		name := strings.TrimSuffix(path.Base(filepath), ".sql")
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil || version <= 0 {
+			return fmt.Errorf("migration: %s: name requires a positive version, e.g. <version>_name.sql", filepath)
+		}
+		if _, ok := Migrations[version]; ok {
+			return fmt.Errorf("migration: %s: version %d already registered", filepath, version)
+		}
+		content, err := fs.ReadFile(fsys, filepath)
+		if err != nil {
+			return err
+		}
+		step, err := parseSQL(version, content)
+		if err != nil {
+			return fmt.Errorf("migration: %s: %w", filepath, err)
+		}
+		Migrations[version] = *step
+	}
+	return nil
+}
+
+// parseSQL splits a .sql migration into its up and, optional, down
+// sections. Each section is sent as is: several statements in it run
+// together, which PostgreSQL wraps in an implicit transaction, so a
+// no-transaction section should hold a single statement. Only blank and
+// comment lines may precede the first section, lest a statement there be
+// dropped unnoticed
+func parseSQL(version int64, content []byte) (*Step, error) {
+	var (
+		response       Step
+		up, down       strings.Builder
+		section        *strings.Builder
+		hasUp, hasDown bool
+	)
+	// split by hand rather than scanned, a seed INSERT may be one long line
+	for i, line := range strings.Split(string(content), "\n") {
+		switch trimmed := strings.TrimSpace(line); trimmed {
+		case sqlMarkerUp:
+			if hasUp {
+				return nil, fmt.Errorf("duplicate %q", sqlMarkerUp)
+			}
+			hasUp, section = true, &up
+		case sqlMarkerDown:
+			if hasDown {
+				return nil, fmt.Errorf("duplicate %q", sqlMarkerDown)
+			}
+			hasDown, section = true, &down
+		case sqlMarkerNoTransaction:
+			response.NoTransaction = true
+		default:
+			if section == nil {
+				if trimmed != "" && !strings.HasPrefix(trimmed, "--") {
+					return nil, fmt.Errorf("line %d: statement outside a %q or %q section", i+1, sqlMarkerUp, sqlMarkerDown)
+				}
+				continue
+			}
+			_, _ = section.WriteString(line)
+			_ = section.WriteByte('\n')
+		}
+	}
+	query := strings.TrimSpace(up.String())
+	if query == "" {
+		return nil, fmt.Errorf("%q section required", sqlMarkerUp)
+	}
+	response.Up = execSQL(query, fmt.Sprintf("Migration-%d", version))
+	// without a down section the migration is irreversible
+	if query := strings.TrimSpace(down.String()); query != "" {
+		response.Down = execSQL(query, fmt.Sprintf("Migration-%d-Down", version))
+	}
+	return &response, nil
+}
+
+func execSQL(query, ctxt string) func(ctx context.Context, tx DB) error {
+	return func(ctx context.Context, tx DB) error {
+		if _, err := tx.Exec(ctx, query); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+			return err
+		}
+		return nil
+	}
+}
@@ -0,0 +1,146 @@
+package migration
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// recorder is a DB keeping the statements sent to it
+type recorder struct {
+	queries []string
+}
+
+func (r *recorder) Exec(_ context.Context, sql string, _ ...interface{}) (pgconn.CommandTag, error) {
+	r.queries = append(r.queries, sql)
+	return pgconn.CommandTag{}, nil
+}
+
+func (r *recorder) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	return nil, nil
+}
+
+func (r *recorder) QueryRow(context.Context, string, ...interface{}) pgx.Row {
+	return nil
+}
+
+func TestParseSQL(t *testing.T) {
+	long := "INSERT INTO t VALUES (" + strings.Repeat("'x', ", 20000) + "'x');"
+	tests := []struct {
+		name          string
+		content       string
+		up            string
+		down          string
+		noTransaction bool
+		err           string
+	}{
+		{
+			name:    "up and down",
+			content: "-- migrate:up\nCREATE INDEX a ON t (c);\n\n-- migrate:down\nDROP INDEX a;\n",
+			up:      "CREATE INDEX a ON t (c);",
+			down:    "DROP INDEX a;",
+		},
+		{
+			name:    "up only",
+			content: "-- migrate:up\nCREATE TABLE t ();",
+			up:      "CREATE TABLE t ();",
+		},
+		{
+			name:    "down before up",
+			content: "-- migrate:down\nDROP TABLE t;\n-- migrate:up\nCREATE TABLE t ();\n",
+			up:      "CREATE TABLE t ();",
+			down:    "DROP TABLE t;",
+		},
+		{
+			name:          "no transaction",
+			content:       "-- migrate:no-transaction\n-- migrate:up\nCREATE INDEX CONCURRENTLY a ON t (c);\n",
+			up:            "CREATE INDEX CONCURRENTLY a ON t (c);",
+			noTransaction: true,
+		},
+		{
+			name:    "markers with surrounding blanks",
+			content: "  -- migrate:up  \r\nSELECT 1;\r\n",
+			up:      "SELECT 1;",
+		},
+		{
+			name:    "comments and blanks before the first section",
+			content: "-- adds t\n\n-- migrate:up\nCREATE TABLE t ();\n",
+			up:      "CREATE TABLE t ();",
+		},
+		{
+			name:    "line longer than 64 KB",
+			content: "-- migrate:up\n" + long + "\n",
+			up:      long,
+		},
+		{
+			name:    "statement before the first section",
+			content: "CREATE TABLE t ();\n-- migrate:up\nSELECT 1;\n",
+			err:     "line 1: statement outside",
+		},
+		{
+			name:    "duplicate up",
+			content: "-- migrate:up\nSELECT 1;\n-- migrate:up\nSELECT 2;\n",
+			err:     `duplicate "-- migrate:up"`,
+		},
+		{
+			name:    "duplicate down",
+			content: "-- migrate:up\nSELECT 1;\n-- migrate:down\nSELECT 2;\n-- migrate:down\nSELECT 3;\n",
+			err:     `duplicate "-- migrate:down"`,
+		},
+		{
+			name:    "missing up",
+			content: "-- migrate:down\nDROP TABLE t;\n",
+			err:     `"-- migrate:up" section required`,
+		},
+		{
+			name:    "empty up",
+			content: "-- migrate:up\n\n-- migrate:down\nDROP TABLE t;\n",
+			err:     `"-- migrate:up" section required`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, err := parseSQL(1, []byte(tt.content))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("parseSQL() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSQL() error = %v", err)
+			}
+			if step.NoTransaction != tt.noTransaction {
+				t.Errorf("NoTransaction = %v, want %v", step.NoTransaction, tt.noTransaction)
+			}
+			if got := run(t, step.Up); got != tt.up {
+				t.Errorf("Up runs %.40q, want %.40q", got, tt.up)
+			}
+			if tt.down == "" {
+				if step.Down != nil {
+					t.Error("Down set without a down section")
+				}
+			} else if got := run(t, step.Down); got != tt.down {
+				t.Errorf("Down runs %.40q, want %.40q", got, tt.down)
+			}
+		})
+	}
+}
+
+func run(t *testing.T, fn func(ctx context.Context, tx DB) error) string {
+	t.Helper()
+	if fn == nil {
+		t.Fatal("section not set")
+	}
+	var tx recorder
+	if err := fn(context.Background(), &tx); err != nil {
+		t.Fatal(err)
+	}
+	if len(tx.queries) != 1 {
+		t.Fatalf("%d statements sent, want 1", len(tx.queries))
+	}
+	return tx.queries[0]
+}
@@ -1,5 +1,9 @@
 package models
 
+import (
+	"errors"
+)
+
 type (
 	Pagination struct {
 		Links struct {
@@ -15,6 +19,30 @@ type (
 		} `json:"info"`
 	}
 
+	// CursorPagination pages by keyset: NextCursor resumes after the last
+	// row returned and is empty on the last page, which is the one thing
+	// known without counting
+	CursorPagination struct {
+		Links struct {
+			Current string `json:"current"`
+			Next    string `json:"next"`
+		} `json:"links"`
+		Info struct {
+			PerPage    int    `json:"per_page"`
+			NextCursor string `json:"next_cursor"`
+		} `json:"info"`
+	}
+
+	// Segment is one structural piece of a verse as laid out in the source
+	// text: a run of text (optionally in a character style such as "wj"),
+	// a paragraph or poetry break, a heading, or a footnote/cross reference
+	Segment struct {
+		Type  string `json:"type"`
+		Style string `json:"style,omitempty"`
+		Level int    `json:"level,omitempty"`
+		Text  string `json:"text,omitempty"`
+	}
+
 	RootCategory struct {
 		ID           int64  `json:"-"`
 		UID          string `json:"id"`
@@ -38,9 +66,22 @@ const (
 	RoleSeller     int = 1
 	RoleSourcing   int = 2
 	RoleCommercial int = 3
+
+	SegmentText      = "text"
+	SegmentParagraph = "paragraph"
+	SegmentPoetry    = "poetry"
+	SegmentBreak     = "break"
+	SegmentHeading   = "heading"
+	SegmentFootnote  = "footnote"
+	SegmentCrossRef  = "crossref"
 )
 
 var (
 	MapPerPageRowsCount = map[int]int{1: 1, 10: 1, 25: 1, 50: 1, 100: 1}
 	PerPageRowsCount    = []int{1, 10, 25, 50, 100}
+
+	ErrInvalidPage    = errors.New("page: expected a positive integer")
+	ErrPageTooLarge   = errors.New("page: past the last row a listing can hold")
+	ErrInvalidPerPage = errors.New("per_page: expected 1, 10, 25, 50 or 100")
+	ErrInvalidCursor  = errors.New("cursor: invalid")
 )
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/roysitumorang/bible/models"
+)
+
+type (
+	// Annotation is a bookmark, highlight or note of a user on a verse
+	// range; ID is the snowflake key, PublicID its sqids encoding
+	Annotation struct {
+		ID           int64     `json:"-"`
+		PublicID     string    `json:"id"`
+		UserID       string    `json:"-"`
+		Type         string    `json:"type"`
+		Reference    string    `json:"reference"`
+		StartOrdinal int       `json:"-"`
+		EndOrdinal   int       `json:"-"`
+		Color        *string   `json:"color,omitempty"`
+		Label        *string   `json:"label,omitempty"`
+		Body         *string   `json:"body,omitempty"`
+		CreatedAt    time.Time `json:"created_at"`
+		UpdatedAt    time.Time `json:"updated_at"`
+	}
+
+	Request struct {
+		Reference string  `json:"ref"`
+		Color     *string `json:"color"`
+		Label     *string `json:"label"`
+		Body      *string `json:"body"`
+	}
+
+	// Filter lists the annotations of a type overlapping Reference, all of
+	// them when empty, PerPage at a time following After
+	Filter struct {
+		UserID       string
+		Type         string
+		Reference    string
+		StartOrdinal int
+		EndOrdinal   int
+		PerPage      int
+		After        *Cursor
+	}
+
+	// Cursor is the sort key of the last annotation of a page
+	Cursor struct {
+		StartOrdinal int
+		EndOrdinal   int
+		ID           int64
+	}
+
+	Response struct {
+		Pagination  *models.CursorPagination `json:"pagination"`
+		Annotations []*Annotation            `json:"annotations"`
+	}
+)
+
+const (
+	TypeBookmark  = "bookmark"
+	TypeHighlight = "highlight"
+	TypeNote      = "note"
+
+	MaxLabelLength = 100
+	MaxBodyLength  = 10000
+
+	DefaultPerPage = 25
+)
+
+var (
+	Colors = map[string]bool{
+		"yellow": true,
+		"green":  true,
+		"blue":   true,
+		"pink":   true,
+		"orange": true,
+		"purple": true,
+	}
+
+	ErrNotFound          = errors.New("annotation not found")
+	ErrReferenceRequired = errors.New("ref: required")
+	ErrSingleRange       = errors.New("ref: expected a single verse range")
+	ErrInvalidColor      = errors.New("color: expected yellow, green, blue, pink, orange or purple")
+	ErrBodyRequired      = errors.New("body: required")
+	ErrLabelTooLong      = errors.New("label: too long")
+	ErrBodyTooLong       = errors.New("body: too long")
+)
@@ -0,0 +1,137 @@
+package presenter
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/middleware"
+	"github.com/roysitumorang/bible/models"
+	"github.com/roysitumorang/bible/modules/annotation/model"
+	"github.com/roysitumorang/bible/modules/annotation/usecase"
+	"github.com/roysitumorang/bible/reference"
+	"go.uber.org/zap"
+)
+
+type (
+	// AnnotationHTTPHandler serves the annotations of one type, mounted
+	// once per type (/bookmarks, /highlights, /notes)
+	AnnotationHTTPHandler struct {
+		annotationUseCase usecase.AnnotationUseCase
+		annotationType    string
+	}
+)
+
+func NewAnnotationHTTPHandler(annotationUseCase usecase.AnnotationUseCase, annotationType string) *AnnotationHTTPHandler {
+	return &AnnotationHTTPHandler{
+		annotationUseCase: annotationUseCase,
+		annotationType:    annotationType,
+	}
+}
+
+func (q *AnnotationHTTPHandler) Mount(r fiber.Router) {
+	r.Use(middleware.BearerAuth())
+	r.Get("", q.FindAnnotations).
+		Post("", q.CreateAnnotation).
+		Get("/:id", q.FindAnnotation).
+		Put("/:id", q.UpdateAnnotation).
+		Delete("/:id", q.DeleteAnnotation)
+}
+
+// FindAnnotations handles GET /v1/notes?ref=John+3&cursor=&per_page=25,
+// listing the annotations overlapping ref a page at a time
+func (q *AnnotationHTTPHandler) FindAnnotations(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "AnnotationHTTPHandler-FindAnnotations"
+	filter := model.Filter{
+		UserID:    middleware.Subject(c),
+		Type:      q.annotationType,
+		Reference: c.Query("ref"),
+	}
+	var err error
+	if filter.PerPage, err = helper.ParsePerPage(c, model.DefaultPerPage); err != nil {
+		return helper.NewResponse(fiber.StatusBadRequest, err.Error(), nil).WriteResponse(c)
+	}
+	annotations, next, err := q.annotationUseCase.FindAnnotations(ctx, &filter, c.Query("cursor"))
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrFindAnnotations")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(
+		fiber.StatusOK,
+		"",
+		&model.Response{
+			Pagination:  helper.NewCursorPagination(c, filter.PerPage, next),
+			Annotations: annotations,
+		},
+	).WriteResponse(c)
+}
+
+func (q *AnnotationHTTPHandler) FindAnnotation(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "AnnotationHTTPHandler-FindAnnotation"
+	response, err := q.annotationUseCase.FindAnnotation(ctx, middleware.Subject(c), q.annotationType, c.Params("id"))
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrFindAnnotation")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(fiber.StatusOK, "", response).WriteResponse(c)
+}
+
+func (q *AnnotationHTTPHandler) CreateAnnotation(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "AnnotationHTTPHandler-CreateAnnotation"
+	var request model.Request
+	if err := c.BodyParser(&request); err != nil {
+		return helper.NewResponse(fiber.StatusBadRequest, err.Error(), nil).WriteResponse(c)
+	}
+	response, err := q.annotationUseCase.CreateAnnotation(ctx, middleware.Subject(c), q.annotationType, &request)
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrCreateAnnotation")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(fiber.StatusCreated, "", response).WriteResponse(c)
+}
+
+func (q *AnnotationHTTPHandler) UpdateAnnotation(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "AnnotationHTTPHandler-UpdateAnnotation"
+	var request model.Request
+	if err := c.BodyParser(&request); err != nil {
+		return helper.NewResponse(fiber.StatusBadRequest, err.Error(), nil).WriteResponse(c)
+	}
+	response, err := q.annotationUseCase.UpdateAnnotation(ctx, middleware.Subject(c), q.annotationType, c.Params("id"), &request)
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrUpdateAnnotation")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(fiber.StatusOK, "", response).WriteResponse(c)
+}
+
+func (q *AnnotationHTTPHandler) DeleteAnnotation(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "AnnotationHTTPHandler-DeleteAnnotation"
+	if err := q.annotationUseCase.DeleteAnnotation(ctx, middleware.Subject(c), q.annotationType, c.Params("id")); err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrDeleteAnnotation")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(fiber.StatusNoContent, "", nil).WriteResponse(c)
+}
+
+func statusCode(err error) int {
+	var parseErr *reference.ParseError
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return fiber.StatusNotFound
+	case errors.As(err, &parseErr),
+		errors.Is(err, models.ErrInvalidCursor),
+		errors.Is(err, model.ErrReferenceRequired),
+		errors.Is(err, model.ErrSingleRange),
+		errors.Is(err, model.ErrInvalidColor),
+		errors.Is(err, model.ErrBodyRequired),
+		errors.Is(err, model.ErrLabelTooLong),
+		errors.Is(err, model.ErrBodyTooLong):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
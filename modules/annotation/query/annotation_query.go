@@ -0,0 +1,207 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/annotation/model"
+	"go.uber.org/zap"
+)
+
+type (
+	annotationQuery struct {
+		dbRead  *pgxpool.Pool
+		dbWrite *pgxpool.Pool
+	}
+)
+
+const (
+	annotationColumns = `"id", "public_id", "user_id", "type", "reference", "start_ordinal", "end_ordinal", "color", "label", "body", "created_at", "updated_at"`
+)
+
+func NewAnnotationQuery(dbRead, dbWrite *pgxpool.Pool) AnnotationQuery {
+	return &annotationQuery{
+		dbRead:  dbRead,
+		dbWrite: dbWrite,
+	}
+}
+
+func (q *annotationQuery) FindAnnotations(ctx context.Context, filter *model.Filter) ([]*model.Annotation, error) {
+	ctxt := "AnnotationQuery-FindAnnotations"
+	var builder strings.Builder
+	_, _ = builder.WriteString(
+		`SELECT ` + annotationColumns + `
+		FROM annotations
+		WHERE "user_id" = $1
+			AND "type" = $2`,
+	)
+	params := []interface{}{filter.UserID, filter.Type}
+	if filter.EndOrdinal > 0 {
+		params = append(params, filter.StartOrdinal, filter.EndOrdinal)
+		_, _ = builder.WriteString(fmt.Sprintf(` AND "start_ordinal" <= $%d AND "end_ordinal" >= $%d`, len(params), len(params)-1))
+	}
+	if after := filter.After; after != nil {
+		params = append(params, after.StartOrdinal, after.EndOrdinal, after.ID)
+		_, _ = builder.WriteString(fmt.Sprintf(` AND ("start_ordinal", "end_ordinal", "id") > ($%d, $%d, $%d)`, len(params)-2, len(params)-1, len(params)))
+	}
+	// one annotation more than the page tells whether another one follows
+	params = append(params, filter.PerPage+1)
+	_, _ = builder.WriteString(fmt.Sprintf(` ORDER BY "start_ordinal", "end_ordinal", "id" LIMIT $%d`, len(params)))
+	rows, err := q.dbRead.Query(ctx, builder.String(), params...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return nil, err
+	}
+	defer rows.Close()
+	var response []*model.Annotation
+	for rows.Next() {
+		annotation, err := scanAnnotation(rows)
+		if err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return nil, err
+		}
+		response = append(response, annotation)
+	}
+	return response, nil
+}
+
+func (q *annotationQuery) FindAnnotation(ctx context.Context, userID, annotationType, publicID string) (*model.Annotation, error) {
+	ctxt := "AnnotationQuery-FindAnnotation"
+	response, err := scanAnnotation(q.dbRead.QueryRow(
+		ctx,
+		`SELECT `+annotationColumns+`
+		FROM annotations
+		WHERE "public_id" = $1
+			AND "user_id" = $2
+			AND "type" = $3`,
+		publicID,
+		userID,
+		annotationType,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, model.ErrNotFound
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return nil, err
+	}
+	return response, nil
+}
+
+func (q *annotationQuery) CreateAnnotation(ctx context.Context, annotation *model.Annotation) error {
+	ctxt := "AnnotationQuery-CreateAnnotation"
+	if err := q.dbWrite.QueryRow(
+		ctx,
+		`INSERT INTO annotations ("id", "public_id", "user_id", "type", "reference", "start_ordinal", "end_ordinal", "color", "label", "body")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING "created_at", "updated_at"`,
+		annotation.ID,
+		annotation.PublicID,
+		annotation.UserID,
+		annotation.Type,
+		annotation.Reference,
+		annotation.StartOrdinal,
+		annotation.EndOrdinal,
+		annotation.Color,
+		annotation.Label,
+		annotation.Body,
+	).Scan(
+		&annotation.CreatedAt,
+		&annotation.UpdatedAt,
+	); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return err
+	}
+	return nil
+}
+
+func (q *annotationQuery) UpdateAnnotation(ctx context.Context, annotation *model.Annotation) error {
+	ctxt := "AnnotationQuery-UpdateAnnotation"
+	err := q.dbWrite.QueryRow(
+		ctx,
+		`UPDATE annotations SET
+			"reference" = $1
+			, "start_ordinal" = $2
+			, "end_ordinal" = $3
+			, "color" = $4
+			, "label" = $5
+			, "body" = $6
+			, "updated_at" = CURRENT_TIMESTAMP
+		WHERE "public_id" = $7
+			AND "user_id" = $8
+			AND "type" = $9
+		RETURNING "id", "created_at", "updated_at"`,
+		annotation.Reference,
+		annotation.StartOrdinal,
+		annotation.EndOrdinal,
+		annotation.Color,
+		annotation.Label,
+		annotation.Body,
+		annotation.PublicID,
+		annotation.UserID,
+		annotation.Type,
+	).Scan(
+		&annotation.ID,
+		&annotation.CreatedAt,
+		&annotation.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrNotFound
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return err
+	}
+	return nil
+}
+
+func (q *annotationQuery) DeleteAnnotation(ctx context.Context, userID, annotationType, publicID string) error {
+	ctxt := "AnnotationQuery-DeleteAnnotation"
+	result, err := q.dbWrite.Exec(
+		ctx,
+		`DELETE FROM annotations
+		WHERE "public_id" = $1
+			AND "user_id" = $2
+			AND "type" = $3`,
+		publicID,
+		userID,
+		annotationType,
+	)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
+
+func scanAnnotation(row pgx.Row) (*model.Annotation, error) {
+	var response model.Annotation
+	if err := row.Scan(
+		&response.ID,
+		&response.PublicID,
+		&response.UserID,
+		&response.Type,
+		&response.Reference,
+		&response.StartOrdinal,
+		&response.EndOrdinal,
+		&response.Color,
+		&response.Label,
+		&response.Body,
+		&response.CreatedAt,
+		&response.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
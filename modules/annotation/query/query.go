@@ -0,0 +1,17 @@
+package query
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/modules/annotation/model"
+)
+
+type (
+	AnnotationQuery interface {
+		FindAnnotations(ctx context.Context, filter *model.Filter) ([]*model.Annotation, error)
+		FindAnnotation(ctx context.Context, userID, annotationType, publicID string) (*model.Annotation, error)
+		CreateAnnotation(ctx context.Context, annotation *model.Annotation) error
+		UpdateAnnotation(ctx context.Context, annotation *model.Annotation) error
+		DeleteAnnotation(ctx context.Context, userID, annotationType, publicID string) error
+	}
+)
@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/roysitumorang/bible/canon"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/annotation/model"
+	"github.com/roysitumorang/bible/modules/annotation/query"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+	"github.com/roysitumorang/bible/reference"
+	"go.uber.org/zap"
+)
+
+type (
+	annotationUseCase struct {
+		annotationQuery query.AnnotationQuery
+		registry        *canon.Registry
+		parser          *reference.Parser
+	}
+)
+
+func NewAnnotationUseCase(annotationQuery query.AnnotationQuery, registry *canon.Registry) AnnotationUseCase {
+	return &annotationUseCase{
+		annotationQuery: annotationQuery,
+		registry:        registry,
+		parser:          reference.NewParser(registry),
+	}
+}
+
+func (q *annotationUseCase) FindAnnotations(ctx context.Context, filter *model.Filter, cursor string) ([]*model.Annotation, string, error) {
+	ctxt := "AnnotationUseCase-FindAnnotations"
+	if filter.Reference != "" {
+		var err error
+		if filter.Reference, filter.StartOrdinal, filter.EndOrdinal, err = q.parseReference(filter.Reference); err != nil {
+			return nil, "", err
+		}
+	}
+	if cursor != "" {
+		var after model.Cursor
+		if err := helper.DecodeCursor(cursor, &after.StartOrdinal, &after.EndOrdinal, &after.ID); err != nil {
+			return nil, "", err
+		}
+		filter.After = &after
+	}
+	response, err := q.annotationQuery.FindAnnotations(ctx, filter)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindAnnotations")
+		return nil, "", err
+	}
+	if len(response) <= filter.PerPage {
+		return response, "", nil
+	}
+	response = response[:filter.PerPage]
+	last := response[len(response)-1]
+	next, err := helper.EncodeCursor(last.StartOrdinal, last.EndOrdinal, last.ID)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrEncodeCursor")
+		return nil, "", err
+	}
+	return response, next, nil
+}
+
+func (q *annotationUseCase) FindAnnotation(ctx context.Context, userID, annotationType, publicID string) (*model.Annotation, error) {
+	ctxt := "AnnotationUseCase-FindAnnotation"
+	response, err := q.annotationQuery.FindAnnotation(ctx, userID, annotationType, publicID)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindAnnotation")
+	}
+	return response, err
+}
+
+func (q *annotationUseCase) CreateAnnotation(ctx context.Context, userID, annotationType string, request *model.Request) (*model.Annotation, error) {
+	ctxt := "AnnotationUseCase-CreateAnnotation"
+	response, err := q.newAnnotation(userID, annotationType, request)
+	if err != nil {
+		return nil, err
+	}
+	if response.ID, response.PublicID, err = helper.GenerateUniqueID(); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrGenerateUniqueID")
+		return nil, err
+	}
+	if err := q.annotationQuery.CreateAnnotation(ctx, response); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCreateAnnotation")
+		return nil, err
+	}
+	return response, nil
+}
+
+// UpdateAnnotation replaces the range and content of an annotation
+func (q *annotationUseCase) UpdateAnnotation(ctx context.Context, userID, annotationType, publicID string, request *model.Request) (*model.Annotation, error) {
+	ctxt := "AnnotationUseCase-UpdateAnnotation"
+	response, err := q.newAnnotation(userID, annotationType, request)
+	if err != nil {
+		return nil, err
+	}
+	response.PublicID = publicID
+	if err := q.annotationQuery.UpdateAnnotation(ctx, response); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrUpdateAnnotation")
+		return nil, err
+	}
+	return response, nil
+}
+
+func (q *annotationUseCase) DeleteAnnotation(ctx context.Context, userID, annotationType, publicID string) error {
+	ctxt := "AnnotationUseCase-DeleteAnnotation"
+	err := q.annotationQuery.DeleteAnnotation(ctx, userID, annotationType, publicID)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrDeleteAnnotation")
+	}
+	return err
+}
+
+// newAnnotation validates request against the annotation type: only
+// highlights keep a colour, only bookmarks a label and only notes a body
+func (q *annotationUseCase) newAnnotation(userID, annotationType string, request *model.Request) (*model.Annotation, error) {
+	response := model.Annotation{
+		UserID: userID,
+		Type:   annotationType,
+	}
+	if strings.TrimSpace(request.Reference) == "" {
+		return nil, model.ErrReferenceRequired
+	}
+	var err error
+	if response.Reference, response.StartOrdinal, response.EndOrdinal, err = q.parseReference(request.Reference); err != nil {
+		return nil, err
+	}
+	switch annotationType {
+	case model.TypeBookmark:
+		if request.Label != nil {
+			label := strings.TrimSpace(*request.Label)
+			if utf8.RuneCountInString(label) > model.MaxLabelLength {
+				return nil, model.ErrLabelTooLong
+			}
+			if label != "" {
+				response.Label = &label
+			}
+		}
+	case model.TypeHighlight:
+		if request.Color == nil || !model.Colors[strings.ToLower(*request.Color)] {
+			return nil, model.ErrInvalidColor
+		}
+		color := strings.ToLower(*request.Color)
+		response.Color = &color
+	case model.TypeNote:
+		if request.Body == nil || strings.TrimSpace(*request.Body) == "" {
+			return nil, model.ErrBodyRequired
+		}
+		body := strings.TrimSpace(*request.Body)
+		if utf8.RuneCountInString(body) > model.MaxBodyLength {
+			return nil, model.ErrBodyTooLong
+		}
+		response.Body = &body
+	}
+	return &response, nil
+}
+
+// parseReference accepts a single range, returned in OSIS form with its
+// bounding ordinals
+func (q *annotationUseCase) parseReference(ref string) (string, int, int, error) {
+	ranges, err := q.parser.Parse(ref)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	if len(ranges) != 1 {
+		return "", 0, 0, model.ErrSingleRange
+	}
+	start, end := scriptureModel.RangeOrdinals(q.registry, ranges[0])
+	return ranges[0].OSIS(), start, end, nil
+}
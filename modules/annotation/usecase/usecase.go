@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/modules/annotation/model"
+)
+
+type (
+	AnnotationUseCase interface {
+		FindAnnotations(ctx context.Context, filter *model.Filter, cursor string) ([]*model.Annotation, string, error)
+		FindAnnotation(ctx context.Context, userID, annotationType, publicID string) (*model.Annotation, error)
+		CreateAnnotation(ctx context.Context, userID, annotationType string, request *model.Request) (*model.Annotation, error)
+		UpdateAnnotation(ctx context.Context, userID, annotationType, publicID string, request *model.Request) (*model.Annotation, error)
+		DeleteAnnotation(ctx context.Context, userID, annotationType, publicID string) error
+	}
+)
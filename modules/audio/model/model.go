@@ -0,0 +1,79 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+)
+
+type (
+	// Recording is the audio of a chapter; StartMs and EndMs bound the
+	// part of it that reads the requested verses, listed in Timestamps
+	Recording struct {
+		ID         int64        `json:"-"`
+		BookID     int          `json:"-"`
+		Book       string       `json:"book"`
+		Chapter    int          `json:"chapter"`
+		Locator    string       `json:"locator"`
+		DurationMs int          `json:"duration_ms"`
+		Narrator   *string      `json:"narrator"`
+		StartMs    int          `json:"start_ms"`
+		EndMs      int          `json:"end_ms"`
+		Timestamps []*Timestamp `json:"timestamps"`
+	}
+
+	Timestamp struct {
+		Verse   int `json:"verse"`
+		StartMs int `json:"start_ms"`
+		EndMs   int `json:"end_ms"`
+	}
+
+	// Passage holds the recordings of Reference, numbered in the
+	// versification of Translation, in canonical order
+	Passage struct {
+		Translation *scriptureModel.Translation `json:"translation"`
+		Reference   string                      `json:"reference"`
+		Recordings  []*Recording                `json:"recordings"`
+	}
+
+	// File is a timing file: the recordings of a translation with the
+	// time each verse starts, in seconds as audio tools export them. A
+	// verse without end lasts until the next one starts, the last one
+	// until the end of the recording.
+	File struct {
+		Translation string         `json:"translation"`
+		Narrator    string         `json:"narrator"`
+		Chapters    []*FileChapter `json:"chapters"`
+	}
+
+	FileChapter struct {
+		Book     string       `json:"book"`
+		Chapter  int          `json:"chapter"`
+		Locator  string       `json:"locator"`
+		Duration float64      `json:"duration"`
+		Narrator string       `json:"narrator"`
+		Verses   []*FileVerse `json:"verses"`
+	}
+
+	FileVerse struct {
+		Verse int      `json:"verse"`
+		Start float64  `json:"start"`
+		End   *float64 `json:"end"`
+	}
+
+	ImportReport struct {
+		Translation string        `json:"translation"`
+		Chapters    int           `json:"chapters"`
+		Verses      int           `json:"verses"`
+		Duration    time.Duration `json:"duration"`
+	}
+)
+
+var (
+	ErrReferenceRequired   = errors.New("ref: required")
+	ErrRecordingNotFound   = errors.New("no audio recorded for the passage")
+	ErrTranslationRequired = errors.New("timing file: translation required")
+	ErrNoChapters          = errors.New("timing file: no chapters found")
+	ErrInvalidTiming       = errors.New("invalid timing")
+)
@@ -0,0 +1,54 @@
+package presenter
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/audio/model"
+	"github.com/roysitumorang/bible/modules/audio/usecase"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+	"github.com/roysitumorang/bible/reference"
+	"go.uber.org/zap"
+)
+
+type (
+	AudioHTTPHandler struct {
+		audioUseCase usecase.AudioUseCase
+	}
+)
+
+func NewAudioHTTPHandler(audioUseCase usecase.AudioUseCase) *AudioHTTPHandler {
+	return &AudioHTTPHandler{
+		audioUseCase: audioUseCase,
+	}
+}
+
+func (q *AudioHTTPHandler) Mount(r fiber.Router) {
+	r.Get("/translations/:translation/audio", q.FindAudio)
+}
+
+// FindAudio handles GET /v1/translations/kjv/audio?ref=John+3:16-18
+func (q *AudioHTTPHandler) FindAudio(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "AudioHTTPHandler-FindAudio"
+	response, err := q.audioUseCase.FindAudio(ctx, c.Params("translation"), c.Query("ref"))
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrFindAudio")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(fiber.StatusOK, "", response).WriteResponse(c)
+}
+
+func statusCode(err error) int {
+	var parseErr *reference.ParseError
+	switch {
+	case errors.Is(err, scriptureModel.ErrTranslationNotFound),
+		errors.Is(err, model.ErrRecordingNotFound):
+		return fiber.StatusNotFound
+	case errors.As(err, &parseErr),
+		errors.Is(err, model.ErrReferenceRequired):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
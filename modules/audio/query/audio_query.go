@@ -0,0 +1,152 @@
+package query
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/audio/model"
+	"go.uber.org/zap"
+)
+
+type (
+	audioQuery struct {
+		dbRead  *pgxpool.Pool
+		dbWrite *pgxpool.Pool
+	}
+)
+
+var (
+	timestampColumns = []string{"recording_id", "verse", "start_ms", "end_ms"}
+)
+
+func NewAudioQuery(dbRead, dbWrite *pgxpool.Pool) AudioQuery {
+	return &audioQuery{
+		dbRead:  dbRead,
+		dbWrite: dbWrite,
+	}
+}
+
+// FindRecordings returns the recordings of the chapters overlapping the
+// ordinal range, each with the timestamps of the verses inside it
+func (q *audioQuery) FindRecordings(ctx context.Context, translationID int64, startOrdinal, endOrdinal int) ([]*model.Recording, error) {
+	ctxt := "AudioQuery-FindRecordings"
+	rows, err := q.dbRead.Query(
+		ctx,
+		`SELECT r."id", r."book_id", b."code", r."chapter", r."locator", r."duration_ms", r."narrator", t."verse", t."start_ms", t."end_ms"
+		FROM audio_recordings r
+		JOIN books b ON b."id" = r."book_id"
+		JOIN audio_timestamps t ON t."recording_id" = r."id"
+		WHERE r."translation_id" = $1
+			AND r."book_id" * 1000000 + r."chapter" * 1000 BETWEEN $2 - $2 % 1000 AND $3
+			AND r."book_id" * 1000000 + r."chapter" * 1000 + t."verse" BETWEEN $2 AND $3
+		ORDER BY r."book_id", r."chapter", t."verse"`,
+		translationID,
+		startOrdinal,
+		endOrdinal,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return nil, err
+	}
+	defer rows.Close()
+	var (
+		response  []*model.Recording
+		recording *model.Recording
+	)
+	for rows.Next() {
+		var (
+			current   model.Recording
+			timestamp model.Timestamp
+		)
+		if err := rows.Scan(
+			&current.ID,
+			&current.BookID,
+			&current.Book,
+			&current.Chapter,
+			&current.Locator,
+			&current.DurationMs,
+			&current.Narrator,
+			&timestamp.Verse,
+			&timestamp.StartMs,
+			&timestamp.EndMs,
+		); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return nil, err
+		}
+		if recording == nil || recording.ID != current.ID {
+			recording = &current
+			response = append(response, recording)
+		}
+		recording.Timestamps = append(recording.Timestamps, &timestamp)
+	}
+	return response, nil
+}
+
+func (q *audioQuery) Begin(ctx context.Context) (pgx.Tx, error) {
+	ctxt := "AudioQuery-Begin"
+	tx, err := q.dbWrite.Begin(ctx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrBegin")
+	}
+	return tx, err
+}
+
+// SaveRecording upserts the recording of a translation chapter
+func (q *audioQuery) SaveRecording(ctx context.Context, tx pgx.Tx, translationID int64, recording *model.Recording) (int64, error) {
+	ctxt := "AudioQuery-SaveRecording"
+	var id int64
+	err := tx.QueryRow(
+		ctx,
+		`INSERT INTO audio_recordings ("translation_id", "book_id", "chapter", "locator", "duration_ms", "narrator")
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT ("translation_id", "book_id", "chapter") DO UPDATE SET
+			"locator" = EXCLUDED."locator"
+			, "duration_ms" = EXCLUDED."duration_ms"
+			, "narrator" = EXCLUDED."narrator"
+			, "updated_at" = CURRENT_TIMESTAMP
+		RETURNING "id"`,
+		translationID,
+		recording.BookID,
+		recording.Chapter,
+		recording.Locator,
+		recording.DurationMs,
+		recording.Narrator,
+	).Scan(&id)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+	}
+	return id, err
+}
+
+func (q *audioQuery) DeleteTimestamps(ctx context.Context, tx pgx.Tx, recordingID int64) (int64, error) {
+	ctxt := "AudioQuery-DeleteTimestamps"
+	result, err := tx.Exec(ctx, `DELETE FROM audio_timestamps WHERE "recording_id" = $1`, recordingID)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+func (q *audioQuery) CopyTimestamps(ctx context.Context, tx pgx.Tx, recordingID int64, timestamps []*model.Timestamp) (int64, error) {
+	ctxt := "AudioQuery-CopyTimestamps"
+	count, err := tx.CopyFrom(
+		ctx,
+		pgx.Identifier{"audio_timestamps"},
+		timestampColumns,
+		pgx.CopyFromSlice(len(timestamps), func(i int) ([]interface{}, error) {
+			timestamp := timestamps[i]
+			return []interface{}{recordingID, timestamp.Verse, timestamp.StartMs, timestamp.EndMs}, nil
+		}),
+	)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCopyFrom")
+	}
+	return count, err
+}
@@ -0,0 +1,18 @@
+package query
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/roysitumorang/bible/modules/audio/model"
+)
+
+type (
+	AudioQuery interface {
+		FindRecordings(ctx context.Context, translationID int64, startOrdinal, endOrdinal int) ([]*model.Recording, error)
+		Begin(ctx context.Context) (pgx.Tx, error)
+		SaveRecording(ctx context.Context, tx pgx.Tx, translationID int64, recording *model.Recording) (int64, error)
+		DeleteTimestamps(ctx context.Context, tx pgx.Tx, recordingID int64) (int64, error)
+		CopyTimestamps(ctx context.Context, tx pgx.Tx, recordingID int64, timestamps []*model.Timestamp) (int64, error)
+	}
+)
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/jackc/pgx/v5"
+	"github.com/roysitumorang/bible/canon"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/audio/model"
+	"github.com/roysitumorang/bible/modules/audio/query"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+	scriptureQuery "github.com/roysitumorang/bible/modules/scripture/query"
+	"github.com/roysitumorang/bible/reference"
+	"go.uber.org/zap"
+)
+
+type (
+	audioUseCase struct {
+		audioQuery     query.AudioQuery
+		scriptureQuery scriptureQuery.ScriptureQuery
+		registry       *canon.Registry
+		parser         *reference.Parser
+	}
+)
+
+func NewAudioUseCase(audioQuery query.AudioQuery, scriptureQuery scriptureQuery.ScriptureQuery, registry *canon.Registry) AudioUseCase {
+	return &audioUseCase{
+		audioQuery:     audioQuery,
+		scriptureQuery: scriptureQuery,
+		registry:       registry,
+		parser:         reference.NewParser(registry),
+	}
+}
+
+// FindAudio returns the segments of the chapter recordings that read
+// ref, numbered in the versification of the translation
+func (q *audioUseCase) FindAudio(ctx context.Context, translationCode, ref string) (*model.Passage, error) {
+	ctxt := "AudioUseCase-FindAudio"
+	if strings.TrimSpace(ref) == "" {
+		return nil, model.ErrReferenceRequired
+	}
+	ranges, err := q.parser.Parse(ref)
+	if err != nil {
+		return nil, err
+	}
+	translation, err := q.scriptureQuery.FindTranslationByCode(ctx, translationCode)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindTranslationByCode")
+		return nil, err
+	}
+	response := model.Passage{
+		Translation: translation,
+		Reference:   reference.Format(ranges),
+	}
+	for _, r := range ranges {
+		start, end := scriptureModel.RangeOrdinals(q.registry, r)
+		recordings, err := q.audioQuery.FindRecordings(ctx, translation.ID, start, end)
+		if err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindRecordings")
+			return nil, err
+		}
+		for _, recording := range recordings {
+			recording.StartMs = recording.Timestamps[0].StartMs
+			recording.EndMs = recording.Timestamps[len(recording.Timestamps)-1].EndMs
+		}
+		response.Recordings = append(response.Recordings, recordings...)
+	}
+	if len(response.Recordings) == 0 {
+		return nil, model.ErrRecordingNotFound
+	}
+	return &response, nil
+}
+
+// Import stores the recordings of a timing file, replacing the ones of
+// the same chapters, in a single transaction; translationCode overrides
+// the translation named in the file
+func (q *audioUseCase) Import(ctx context.Context, r io.Reader, translationCode string) (*model.ImportReport, error) {
+	ctxt := "AudioUseCase-Import"
+	now := time.Now()
+	var file model.File
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("timing file: %w", err)
+	}
+	if translationCode == "" {
+		translationCode = file.Translation
+	}
+	if translationCode == "" {
+		return nil, model.ErrTranslationRequired
+	}
+	if len(file.Chapters) == 0 {
+		return nil, model.ErrNoChapters
+	}
+	translation, err := q.scriptureQuery.FindTranslationByCode(ctx, translationCode)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindTranslationByCode")
+		return nil, err
+	}
+	recordings := make([]*model.Recording, len(file.Chapters))
+	for i, chapter := range file.Chapters {
+		if recordings[i], err = q.newRecording(&file, chapter); err != nil {
+			return nil, err
+		}
+	}
+	tx, err := q.audioQuery.Begin(ctx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrBegin")
+		return nil, err
+	}
+	defer func() {
+		if errRollback := tx.Rollback(ctx); errRollback != nil && !errors.Is(errRollback, pgx.ErrTxClosed) {
+			helper.Capture(ctx, zap.ErrorLevel, errRollback, ctxt, "ErrRollback")
+		}
+	}()
+	response := model.ImportReport{
+		Translation: translation.Code,
+		Chapters:    len(recordings),
+	}
+	for _, recording := range recordings {
+		if recording.ID, err = q.audioQuery.SaveRecording(ctx, tx, translation.ID, recording); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrSaveRecording")
+			return nil, err
+		}
+		if _, err := q.audioQuery.DeleteTimestamps(ctx, tx, recording.ID); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrDeleteTimestamps")
+			return nil, err
+		}
+		count, err := q.audioQuery.CopyTimestamps(ctx, tx, recording.ID, recording.Timestamps)
+		if err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCopyTimestamps")
+			return nil, err
+		}
+		response.Verses += int(count)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCommit")
+		return nil, err
+	}
+	response.Duration = time.Since(now)
+	return &response, nil
+}
+
+// newRecording validates a chapter of the timing file and converts its
+// times to milliseconds, filling in the missing verse ends
+func (q *audioUseCase) newRecording(file *model.File, chapter *model.FileChapter) (*model.Recording, error) {
+	book, ok := q.registry.ResolveBook(chapter.Book)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown book %q", model.ErrInvalidTiming, chapter.Book)
+	}
+	name := fmt.Sprintf("%s %d", book.Code, chapter.Chapter)
+	switch {
+	case chapter.Chapter < 1 || chapter.Chapter > scriptureModel.MaxChapter:
+		return nil, fmt.Errorf("%w: %s: invalid chapter", model.ErrInvalidTiming, name)
+	case strings.TrimSpace(chapter.Locator) == "":
+		return nil, fmt.Errorf("%w: %s: locator required", model.ErrInvalidTiming, name)
+	case chapter.Duration <= 0:
+		return nil, fmt.Errorf("%w: %s: duration required", model.ErrInvalidTiming, name)
+	case len(chapter.Verses) == 0:
+		return nil, fmt.Errorf("%w: %s: no verses", model.ErrInvalidTiming, name)
+	}
+	response := model.Recording{
+		BookID:     book.Order,
+		Book:       book.Code,
+		Chapter:    chapter.Chapter,
+		Locator:    strings.TrimSpace(chapter.Locator),
+		DurationMs: milliseconds(chapter.Duration),
+		Timestamps: make([]*model.Timestamp, len(chapter.Verses)),
+	}
+	narrator := chapter.Narrator
+	if narrator == "" {
+		narrator = file.Narrator
+	}
+	if narrator = strings.TrimSpace(narrator); narrator != "" {
+		response.Narrator = &narrator
+	}
+	for i, verse := range chapter.Verses {
+		end := chapter.Duration
+		switch {
+		case verse.End != nil:
+			end = *verse.End
+		case i+1 < len(chapter.Verses):
+			end = chapter.Verses[i+1].Start
+		}
+		timestamp := model.Timestamp{
+			Verse:   verse.Verse,
+			StartMs: milliseconds(verse.Start),
+			EndMs:   milliseconds(end),
+		}
+		switch {
+		case verse.Verse < 1 || verse.Verse > scriptureModel.MaxVerse:
+			return nil, fmt.Errorf("%w: %s: invalid verse %d", model.ErrInvalidTiming, name, verse.Verse)
+		case i > 0 && verse.Verse <= chapter.Verses[i-1].Verse:
+			return nil, fmt.Errorf("%w: %s: verse %d out of order", model.ErrInvalidTiming, name, verse.Verse)
+		case timestamp.StartMs < 0 || timestamp.StartMs >= timestamp.EndMs || timestamp.EndMs > response.DurationMs:
+			return nil, fmt.Errorf("%w: %s:%d: start %.3fs, end %.3fs", model.ErrInvalidTiming, name, verse.Verse, verse.Start, end)
+		}
+		response.Timestamps[i] = &timestamp
+	}
+	return &response, nil
+}
+
+func milliseconds(seconds float64) int {
+	return int(math.Round(seconds * 1000))
+}
@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+	"io"
+
+	"github.com/roysitumorang/bible/modules/audio/model"
+)
+
+type (
+	AudioUseCase interface {
+		FindAudio(ctx context.Context, translationCode, ref string) (*model.Passage, error)
+		Import(ctx context.Context, r io.Reader, translationCode string) (*model.ImportReport, error)
+	}
+)
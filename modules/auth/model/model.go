@@ -0,0 +1,53 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+type (
+	// User is an account able to request tokens; PublicID, the sqids
+	// encoding of ID, is the token subject
+	User struct {
+		ID           int64     `json:"-"`
+		PublicID     string    `json:"id"`
+		Username     string    `json:"username"`
+		PasswordHash string    `json:"-"`
+		CreatedAt    time.Time `json:"created_at"`
+		UpdatedAt    time.Time `json:"updated_at"`
+	}
+
+	TokenRequest struct {
+		Username string `json:"username" form:"username"`
+		Password string `json:"password" form:"password"`
+	}
+
+	Token struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+
+	Config struct {
+		Issuer   string
+		Audience string
+		TTL      time.Duration
+	}
+)
+
+const (
+	TokenType = "Bearer"
+
+	DefaultTTL        = time.Hour
+	MinPasswordLength = 8
+	MaxUsernameLength = 64
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrUsernameTaken      = errors.New("username already taken")
+	ErrInvalidUsername    = errors.New("username: 1 to 64 letters, digits, '.', '_' or '-'")
+	ErrPasswordTooShort   = errors.New("password: at least 8 characters")
+	// the token endpoint is not configured, see LoadConfig
+	ErrTokensUnavailable = errors.New("token issuing is unavailable")
+)
@@ -0,0 +1,55 @@
+package presenter
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/auth/model"
+	"github.com/roysitumorang/bible/modules/auth/usecase"
+	"go.uber.org/zap"
+)
+
+type (
+	AuthHTTPHandler struct {
+		authUseCase usecase.AuthUseCase
+	}
+)
+
+func NewAuthHTTPHandler(authUseCase usecase.AuthUseCase) *AuthHTTPHandler {
+	return &AuthHTTPHandler{
+		authUseCase: authUseCase,
+	}
+}
+
+func (q *AuthHTTPHandler) Mount(r fiber.Router) {
+	r.Post("", q.IssueToken)
+}
+
+// IssueToken handles POST /v1/tokens with a JSON or form body holding
+// username and password
+func (q *AuthHTTPHandler) IssueToken(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "AuthHTTPHandler-IssueToken"
+	var request model.TokenRequest
+	if err := c.BodyParser(&request); err != nil {
+		return helper.NewResponse(fiber.StatusBadRequest, err.Error(), nil).WriteResponse(c)
+	}
+	response, err := q.authUseCase.IssueToken(ctx, &request)
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrIssueToken")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return helper.NewResponse(fiber.StatusOK, "", response).WriteResponse(c)
+}
+
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, model.ErrInvalidCredentials):
+		return fiber.StatusUnauthorized
+	case errors.Is(err, model.ErrTokensUnavailable):
+		return fiber.StatusServiceUnavailable
+	}
+	return fiber.StatusInternalServerError
+}
@@ -0,0 +1,85 @@
+package query
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/auth/model"
+	"go.uber.org/zap"
+)
+
+type (
+	authQuery struct {
+		dbRead  *pgxpool.Pool
+		dbWrite *pgxpool.Pool
+	}
+)
+
+const (
+	uniqueViolation = "23505"
+)
+
+func NewAuthQuery(dbRead, dbWrite *pgxpool.Pool) AuthQuery {
+	return &authQuery{
+		dbRead:  dbRead,
+		dbWrite: dbWrite,
+	}
+}
+
+// FindUserByUsername returns nil without error when there is no such user
+func (q *authQuery) FindUserByUsername(ctx context.Context, username string) (*model.User, error) {
+	ctxt := "AuthQuery-FindUserByUsername"
+	var response model.User
+	err := q.dbRead.QueryRow(
+		ctx,
+		`SELECT "id", "public_id", "username", "password_hash", "created_at", "updated_at"
+		FROM users
+		WHERE "username" = $1`,
+		username,
+	).Scan(
+		&response.ID,
+		&response.PublicID,
+		&response.Username,
+		&response.PasswordHash,
+		&response.CreatedAt,
+		&response.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (q *authQuery) CreateUser(ctx context.Context, user *model.User) error {
+	ctxt := "AuthQuery-CreateUser"
+	err := q.dbWrite.QueryRow(
+		ctx,
+		`INSERT INTO users ("id", "public_id", "username", "password_hash")
+		VALUES ($1, $2, $3, $4)
+		RETURNING "created_at", "updated_at"`,
+		user.ID,
+		user.PublicID,
+		user.Username,
+		user.PasswordHash,
+	).Scan(
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return model.ErrUsernameTaken
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package query
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/modules/auth/model"
+)
+
+type (
+	AuthQuery interface {
+		FindUserByUsername(ctx context.Context, username string) (*model.User, error)
+		CreateUser(ctx context.Context, user *model.User) error
+	}
+)
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/keys"
+	"github.com/roysitumorang/bible/modules/auth/model"
+	"github.com/roysitumorang/bible/modules/auth/query"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type (
+	authUseCase struct {
+		authQuery  query.AuthQuery
+		config     *model.Config
+		privateKey func() (*rsa.PrivateKey, error)
+	}
+)
+
+var (
+	usernamePattern = regexp.MustCompile(`^[a-z0-9._-]+$`)
+	// compared against when the user does not exist, so unknown and known
+	// usernames take as long to reject
+	dummyHash = sync.OnceValue(func() []byte {
+		hash, _ := bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+		return hash
+	})
+)
+
+func NewAuthUseCase(authQuery query.AuthQuery, config *model.Config) AuthUseCase {
+	return &authUseCase{
+		authQuery:  authQuery,
+		config:     config,
+		privateKey: sync.OnceValues(keys.InitPrivateKey),
+	}
+}
+
+// LoadConfig reads JWT_ISSUER, JWT_AUDIENCE (GOOGLE_API_CLIENT_ID when
+// empty, the audience BearerVerify has always accepted) and JWT_TTL. The
+// issuer and audience are only required to issue tokens, see IssueToken
+func LoadConfig() (*model.Config, error) {
+	response := model.Config{
+		Issuer:   os.Getenv("JWT_ISSUER"),
+		Audience: os.Getenv("JWT_AUDIENCE"),
+		TTL:      model.DefaultTTL,
+	}
+	if response.Audience == "" {
+		response.Audience = os.Getenv("GOOGLE_API_CLIENT_ID")
+	}
+	if envTTL, ok := os.LookupEnv("JWT_TTL"); ok && envTTL != "" {
+		ttl, err := time.ParseDuration(envTTL)
+		if err != nil || ttl <= 0 {
+			return nil, errors.New("env JWT_TTL requires a positive duration, e.g. 1h")
+		}
+		response.TTL = ttl
+	}
+	return &response, nil
+}
+
+// IssueToken checks the credentials and mints an RS256 access token whose
+// subject is the public ID of the user
+func (q *authUseCase) IssueToken(ctx context.Context, request *model.TokenRequest) (*model.Token, error) {
+	ctxt := "AuthUseCase-IssueToken"
+	// BearerVerify rejects the tokens lacking either
+	if q.config.Issuer == "" || q.config.Audience == "" {
+		helper.Log(ctx, zap.WarnLevel, "env JWT_ISSUER and JWT_AUDIENCE (or GOOGLE_API_CLIENT_ID) are required to issue tokens", ctxt, "ErrTokensUnavailable")
+		return nil, model.ErrTokensUnavailable
+	}
+	user, err := q.authQuery.FindUserByUsername(ctx, strings.ToLower(strings.TrimSpace(request.Username)))
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindUserByUsername")
+		return nil, err
+	}
+	if user == nil {
+		_ = bcrypt.CompareHashAndPassword(dummyHash(), helper.String2ByteSlice(request.Password))
+		return nil, model.ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword(helper.String2ByteSlice(user.PasswordHash), helper.String2ByteSlice(request.Password)); err != nil {
+		return nil, model.ErrInvalidCredentials
+	}
+	privateKey, err := q.privateKey()
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrInitPrivateKey")
+		return nil, err
+	}
+	_, tokenID, err := helper.GenerateUniqueID()
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrGenerateUniqueID")
+		return nil, err
+	}
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		ID:        tokenID,
+		Issuer:    q.config.Issuer,
+		Subject:   user.PublicID,
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(q.config.TTL)),
+		Audience:  jwt.ClaimStrings{q.config.Audience},
+	}
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(privateKey)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrSignedString")
+		return nil, err
+	}
+	return &model.Token{
+		AccessToken: accessToken,
+		TokenType:   model.TokenType,
+		ExpiresIn:   int(q.config.TTL.Seconds()),
+	}, nil
+}
+
+func (q *authUseCase) CreateUser(ctx context.Context, username, password string) (*model.User, error) {
+	ctxt := "AuthUseCase-CreateUser"
+	username = strings.ToLower(strings.TrimSpace(username))
+	if len(username) > model.MaxUsernameLength || !usernamePattern.MatchString(username) {
+		return nil, model.ErrInvalidUsername
+	}
+	if utf8.RuneCountInString(password) < model.MinPasswordLength {
+		return nil, model.ErrPasswordTooShort
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword(helper.String2ByteSlice(password), bcrypt.DefaultCost)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrGenerateFromPassword")
+		return nil, err
+	}
+	response := model.User{
+		Username:     username,
+		PasswordHash: helper.ByteSlice2String(passwordHash),
+	}
+	if response.ID, response.PublicID, err = helper.GenerateUniqueID(); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrGenerateUniqueID")
+		return nil, err
+	}
+	if err := q.authQuery.CreateUser(ctx, &response); err != nil {
+		if !errors.Is(err, model.ErrUsernameTaken) {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCreateUser")
+		}
+		return nil, fmt.Errorf("%s: %w", username, err)
+	}
+	return &response, nil
+}
@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/modules/auth/model"
+)
+
+type (
+	AuthUseCase interface {
+		IssueToken(ctx context.Context, request *model.TokenRequest) (*model.Token, error)
+		CreateUser(ctx context.Context, username, password string) (*model.User, error)
+	}
+)
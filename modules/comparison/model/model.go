@@ -0,0 +1,68 @@
+package model
+
+import (
+	"errors"
+
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+)
+
+type (
+	Request struct {
+		Reference    string
+		Translations []string
+		// numbering of Reference, the one of the first translation when
+		// empty
+		Versification string
+	}
+
+	// Comparison lines the translations up row by row; every row is a
+	// verse of Reference numbered in Versification and holds one cell
+	// per translation, in the requested order
+	Comparison struct {
+		Reference     string                        `json:"reference"`
+		Versification string                        `json:"versification"`
+		Translations  []*scriptureModel.Translation `json:"translations"`
+		Rows          []*Row                        `json:"rows"`
+	}
+
+	Row struct {
+		Reference string  `json:"reference"`
+		Book      string  `json:"book"`
+		Chapter   int     `json:"chapter"`
+		Verse     int     `json:"verse"`
+		Cells     []*Cell `json:"cells"`
+	}
+
+	// Cell holds the verses of one translation on a row. A verse spanning
+	// several rows sits on the first one, the others are marked merged and
+	// point back to it through MergedWith.
+	Cell struct {
+		Status     string                  `json:"status"`
+		MergedWith string                  `json:"merged_with,omitempty"`
+		Verses     []*scriptureModel.Verse `json:"verses"`
+	}
+)
+
+const (
+	StatusPresent = "present"
+	// the translation has no counterpart for the verse
+	StatusMissing = "missing"
+	// the verse is part of a verse shown on an earlier row
+	StatusMerged = "merged"
+	// the translation splits the verse in several ones
+	StatusSplit = "split"
+
+	MinTranslations = 2
+	MaxTranslations = 6
+	// budget of a comparison: ranges of the reference, verses read per
+	// translation
+	MaxRanges = 20
+	MaxVerses = 500
+)
+
+var (
+	ErrReferenceRequired    = errors.New("ref: required")
+	ErrTranslationsCount    = errors.New("translations: expected 2 to 6 translation codes")
+	ErrDuplicateTranslation = errors.New("translations: duplicate translation code")
+	ErrReferenceTooLong     = errors.New("ref: at most 20 ranges and 500 verses")
+)
@@ -0,0 +1,66 @@
+package presenter
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/comparison/model"
+	"github.com/roysitumorang/bible/modules/comparison/usecase"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+	"github.com/roysitumorang/bible/reference"
+	"go.uber.org/zap"
+)
+
+type (
+	ComparisonHTTPHandler struct {
+		comparisonUseCase usecase.ComparisonUseCase
+	}
+)
+
+func NewComparisonHTTPHandler(comparisonUseCase usecase.ComparisonUseCase) *ComparisonHTTPHandler {
+	return &ComparisonHTTPHandler{
+		comparisonUseCase: comparisonUseCase,
+	}
+}
+
+func (q *ComparisonHTTPHandler) Mount(r fiber.Router) {
+	r.Get("", q.Compare)
+}
+
+// Compare handles GET /v1/comparisons?ref=Mal+4&translations=kjv,tb,wlc&versification=kjv
+func (q *ComparisonHTTPHandler) Compare(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "ComparisonHTTPHandler-Compare"
+	request := model.Request{
+		Reference:     c.Query("ref"),
+		Versification: c.Query("versification"),
+	}
+	if translations := c.Query("translations"); translations != "" {
+		request.Translations = strings.Split(translations, ",")
+	}
+	response, err := q.comparisonUseCase.Compare(ctx, &request)
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrCompare")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(fiber.StatusOK, "", response).WriteResponse(c)
+}
+
+func statusCode(err error) int {
+	var parseErr *reference.ParseError
+	switch {
+	case errors.Is(err, scriptureModel.ErrTranslationNotFound),
+		errors.Is(err, scriptureModel.ErrVerseNotFound):
+		return fiber.StatusNotFound
+	case errors.As(err, &parseErr),
+		errors.Is(err, scriptureModel.ErrUnknownVersification),
+		errors.Is(err, model.ErrReferenceRequired),
+		errors.Is(err, model.ErrTranslationsCount),
+		errors.Is(err, model.ErrDuplicateTranslation),
+		errors.Is(err, model.ErrReferenceTooLong):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/roysitumorang/bible/canon"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/comparison/model"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+	scriptureQuery "github.com/roysitumorang/bible/modules/scripture/query"
+	"github.com/roysitumorang/bible/reference"
+	"github.com/roysitumorang/bible/versification"
+	"go.uber.org/zap"
+)
+
+type (
+	comparisonUseCase struct {
+		scriptureQuery scriptureQuery.ScriptureQuery
+		registry       *canon.Registry
+		versifications *versification.Registry
+		parser         *reference.Parser
+	}
+
+	// placement is a verse of one translation and the row ordinals, in
+	// the numbering of the comparison, it corresponds to
+	placement struct {
+		column    int
+		verse     *scriptureModel.Verse
+		intervals []versification.Interval
+		rows      []int
+	}
+)
+
+func NewComparisonUseCase(scriptureQuery scriptureQuery.ScriptureQuery, registry *canon.Registry, versifications *versification.Registry) ComparisonUseCase {
+	return &comparisonUseCase{
+		scriptureQuery: scriptureQuery,
+		registry:       registry,
+		versifications: versifications,
+		parser:         reference.NewParser(registry),
+	}
+}
+
+// Compare fetches the verses of request.Reference in every translation,
+// mapping the reference to the numbering of each one, and maps every
+// verse found back to the numbering of the comparison to line them up
+func (q *comparisonUseCase) Compare(ctx context.Context, request *model.Request) (*model.Comparison, error) {
+	ctxt := "ComparisonUseCase-Compare"
+	if request.Reference = strings.TrimSpace(request.Reference); request.Reference == "" {
+		return nil, model.ErrReferenceRequired
+	}
+	if len(request.Translations) < model.MinTranslations || len(request.Translations) > model.MaxTranslations {
+		return nil, model.ErrTranslationsCount
+	}
+	ranges, err := q.parser.Parse(request.Reference)
+	if err != nil {
+		return nil, err
+	}
+	if len(ranges) > model.MaxRanges {
+		return nil, model.ErrReferenceTooLong
+	}
+	response := model.Comparison{
+		Reference:    reference.Format(ranges),
+		Translations: make([]*scriptureModel.Translation, len(request.Translations)),
+	}
+	mapTranslations := map[int64]bool{}
+	for i, code := range request.Translations {
+		translation, err := q.scriptureQuery.FindTranslationByCode(ctx, strings.TrimSpace(code))
+		if err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindTranslationByCode")
+			return nil, err
+		}
+		if mapTranslations[translation.ID] {
+			return nil, fmt.Errorf("%w: %s", model.ErrDuplicateTranslation, translation.Code)
+		}
+		mapTranslations[translation.ID] = true
+		response.Translations[i] = translation
+	}
+	if response.Versification = strings.ToLower(request.Versification); response.Versification == "" {
+		response.Versification = response.Translations[0].Versification
+	}
+	if _, ok := q.versifications.Scheme(response.Versification); !ok {
+		return nil, fmt.Errorf("%w: %s", scriptureModel.ErrUnknownVersification, response.Versification)
+	}
+	windows := make([]versification.Interval, len(ranges))
+	for i, r := range ranges {
+		windows[i].Start, windows[i].End = scriptureModel.RangeOrdinals(q.registry, r)
+	}
+	var placements []*placement
+	mapRows := map[int]bool{}
+	for column, translation := range response.Translations {
+		verses, err := q.findVerses(ctx, translation, response.Versification, windows)
+		if err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindVerses")
+			return nil, err
+		}
+		for _, verse := range verses {
+			ordinal := scriptureModel.Ordinal(verse.BookID, verse.Chapter, verse.Verse)
+			intervals, err := q.versifications.Map(translation.Versification, response.Versification, ordinal, ordinal)
+			if err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrMap")
+				return nil, err
+			}
+			if intervals = clip(intervals, windows); len(intervals) == 0 {
+				continue
+			}
+			// a verse mapped to a single verse settles a row, one mapped
+			// to a range only spans the rows other verses settle
+			if len(intervals) == 1 && intervals[0].Start == intervals[0].End {
+				mapRows[intervals[0].Start] = true
+			}
+			placements = append(placements, &placement{
+				column:    column,
+				verse:     verse,
+				intervals: intervals,
+			})
+		}
+	}
+	// the row ordinals, kept sorted to look up the ones an interval spans
+	ordinals := make([]int, 0, len(mapRows))
+	for ordinal := range mapRows {
+		ordinals = append(ordinals, ordinal)
+	}
+	sort.Ints(ordinals)
+	for _, placement := range placements {
+		for _, interval := range placement.intervals {
+			for i := sort.SearchInts(ordinals, interval.Start); i < len(ordinals) && ordinals[i] <= interval.End; i++ {
+				placement.rows = append(placement.rows, ordinals[i])
+			}
+		}
+		if len(placement.rows) == 0 {
+			ordinal := placement.intervals[0].Start
+			ordinals = slices.Insert(ordinals, sort.SearchInts(ordinals, ordinal), ordinal)
+			placement.rows = []int{ordinal}
+		}
+		sort.Ints(placement.rows)
+		placement.rows = slices.Compact(placement.rows)
+	}
+	rows := make(map[int]*model.Row, len(ordinals))
+	response.Rows = make([]*model.Row, len(ordinals))
+	for i, ordinal := range ordinals {
+		rows[ordinal] = q.newRow(ordinal, len(response.Translations))
+		response.Rows[i] = rows[ordinal]
+	}
+	for _, placement := range placements {
+		first := rows[placement.rows[0]]
+		cell := first.Cells[placement.column]
+		cell.Verses = append(cell.Verses, placement.verse)
+		for _, ordinal := range placement.rows[1:] {
+			rows[ordinal].Cells[placement.column].MergedWith = first.Reference
+		}
+	}
+	for _, row := range response.Rows {
+		for _, cell := range row.Cells {
+			switch {
+			case len(cell.Verses) > 1:
+				cell.Status = model.StatusSplit
+			case len(cell.Verses) == 1:
+				cell.Status = model.StatusPresent
+			case cell.MergedWith != "":
+				cell.Status = model.StatusMerged
+			}
+		}
+	}
+	if len(response.Rows) == 0 {
+		return nil, scriptureModel.ErrVerseNotFound
+	}
+	return &response, nil
+}
+
+// findVerses reads the verses of windows, numbered in scheme, from
+// translation in its own numbering, at most model.MaxVerses of them
+func (q *comparisonUseCase) findVerses(ctx context.Context, translation *scriptureModel.Translation, scheme string, windows []versification.Interval) ([]*scriptureModel.Verse, error) {
+	ctxt := "ComparisonUseCase-findVerses"
+	var (
+		response []*scriptureModel.Verse
+		// rows read, overlapping windows may read a verse twice
+		read int
+	)
+	mapVerses := map[int64]bool{}
+	for _, window := range windows {
+		intervals, err := q.versifications.Map(scheme, translation.Versification, window.Start, window.End)
+		if err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrMap")
+			return nil, err
+		}
+		for _, interval := range intervals {
+			verses, err := q.scriptureQuery.FindVerses(
+				ctx,
+				&scriptureModel.VerseFilter{
+					TranslationID: translation.ID,
+					StartOrdinal:  interval.Start,
+					EndOrdinal:    interval.End,
+					// one more than the budget tells it is exceeded
+					Limit: model.MaxVerses + 1 - read,
+				},
+			)
+			if err != nil {
+				helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindVerses")
+				return nil, err
+			}
+			if read += len(verses); read > model.MaxVerses {
+				return nil, model.ErrReferenceTooLong
+			}
+			for _, verse := range verses {
+				if !mapVerses[verse.ID] {
+					mapVerses[verse.ID] = true
+					response = append(response, verse)
+				}
+			}
+		}
+	}
+	return response, nil
+}
+
+func (q *comparisonUseCase) newRow(ordinal, columns int) *model.Row {
+	bookID, chapter, verse := scriptureModel.SplitOrdinal(ordinal)
+	book := canon.Books[bookID-1]
+	response := model.Row{
+		Reference: fmt.Sprintf("%s.%d.%d", book.Code, chapter, verse),
+		Book:      book.Code,
+		Chapter:   chapter,
+		Verse:     verse,
+		Cells:     make([]*model.Cell, columns),
+	}
+	for i := range response.Cells {
+		response.Cells[i] = &model.Cell{
+			Status: model.StatusMissing,
+			Verses: []*scriptureModel.Verse{},
+		}
+	}
+	return &response
+}
+
+// clip keeps the parts of intervals inside windows
+func clip(intervals, windows []versification.Interval) []versification.Interval {
+	var response []versification.Interval
+	for _, interval := range intervals {
+		for _, window := range windows {
+			start, end := max(interval.Start, window.Start), min(interval.End, window.End)
+			if start <= end {
+				response = append(response, versification.Interval{Start: start, End: end})
+			}
+		}
+	}
+	return response
+}
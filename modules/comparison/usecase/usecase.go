@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/modules/comparison/model"
+)
+
+type (
+	ComparisonUseCase interface {
+		Compare(ctx context.Context, request *model.Request) (*model.Comparison, error)
+	}
+)
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/roysitumorang/bible/models"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+)
+
+type (
+	// Word is a normalized word form with the number of times it occurs
+	// and the number of verses it occurs in
+	Word struct {
+		Word        string `json:"word"`
+		Occurrences int    `json:"occurrences"`
+		Verses      int    `json:"verses"`
+	}
+
+	// Entry is a concordance row: the verses of a book a word form occurs
+	// in, by ordinal
+	Entry struct {
+		Word        string
+		BookID      int
+		Occurrences int
+		Ordinals    []int32
+	}
+
+	// Filter narrows the concordance of Translation to Book or Testament;
+	// Word pages through its verses, Limit caps the frequency list
+	Filter struct {
+		Translation   string
+		TranslationID int64
+		Word          string
+		Book          string
+		BookID        int
+		Testament     string
+		Page          int
+		PerPage       int
+		Limit         int
+	}
+
+	// Build is the state of the concordance of a translation: it is stale
+	// while BuiltAt lags behind UpdatedAt
+	Build struct {
+		TranslationID int64
+		UpdatedAt     time.Time
+		BuiltAt       *time.Time
+	}
+
+	Config struct {
+		// cron spec of the rebuild of stale concordances, evaluated in
+		// helper.LoadTimeZone
+		Schedule string
+	}
+
+	Response struct {
+		Translation *scriptureModel.Translation `json:"translation"`
+		Book        string                      `json:"book,omitempty"`
+		Word        *Word                       `json:"word"`
+		Pagination  *models.Pagination          `json:"pagination"`
+		Verses      []*scriptureModel.Verse     `json:"verses"`
+	}
+
+	FrequencyResponse struct {
+		Translation *scriptureModel.Translation `json:"translation"`
+		Book        string                      `json:"book,omitempty"`
+		Testament   string                      `json:"testament,omitempty"`
+		Words       []*Word                     `json:"words"`
+	}
+
+	BuildReport struct {
+		Translation string        `json:"translation"`
+		Verses      int           `json:"verses"`
+		Words       int           `json:"words"`
+		Occurrences int           `json:"occurrences"`
+		Duration    time.Duration `json:"duration"`
+	}
+)
+
+const (
+	DefaultSchedule = "*/5 * * * *"
+
+	DefaultPerPage = 10
+	DefaultLimit   = 20
+	MaxLimit       = 100
+)
+
+var (
+	ErrWordRequired     = errors.New("word: required")
+	ErrInvalidWord      = errors.New("word: expected a single word")
+	ErrUnknownBook      = errors.New("unknown book")
+	ErrInvalidTestament = errors.New("testament: expected OT or NT")
+	ErrInvalidLimit     = errors.New("limit: expected 1 to 100")
+	ErrNotBuilt         = errors.New("concordance not built yet")
+	ErrWordNotFound     = errors.New("word not found")
+)
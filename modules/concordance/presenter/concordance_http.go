@@ -0,0 +1,88 @@
+package presenter
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/concordance/model"
+	"github.com/roysitumorang/bible/modules/concordance/usecase"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+	"go.uber.org/zap"
+)
+
+type (
+	ConcordanceHTTPHandler struct {
+		concordanceUseCase usecase.ConcordanceUseCase
+	}
+)
+
+func NewConcordanceHTTPHandler(concordanceUseCase usecase.ConcordanceUseCase) *ConcordanceHTTPHandler {
+	return &ConcordanceHTTPHandler{
+		concordanceUseCase: concordanceUseCase,
+	}
+}
+
+func (q *ConcordanceHTTPHandler) Mount(r fiber.Router) {
+	r.Get("/translations/:translation/concordance", q.FindFrequencies).
+		Get("/translations/:translation/concordance/:word", q.FindOccurrences)
+}
+
+// FindFrequencies handles GET /v1/translations/kjv/concordance?book=John&testament=NT&limit=20
+func (q *ConcordanceHTTPHandler) FindFrequencies(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "ConcordanceHTTPHandler-FindFrequencies"
+	response, err := q.concordanceUseCase.FindFrequencies(
+		ctx,
+		&model.Filter{
+			Translation: c.Params("translation"),
+			Book:        c.Query("book"),
+			Testament:   c.Query("testament"),
+			Limit:       c.QueryInt("limit", model.DefaultLimit),
+		},
+	)
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrFindFrequencies")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(fiber.StatusOK, "", response).WriteResponse(c)
+}
+
+// FindOccurrences handles GET /v1/translations/kjv/concordance/love?book=1John&page=1&per_page=10
+func (q *ConcordanceHTTPHandler) FindOccurrences(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "ConcordanceHTTPHandler-FindOccurrences"
+	filter := model.Filter{
+		Translation: c.Params("translation"),
+		Word:        c.Params("word"),
+		Book:        c.Query("book"),
+		Testament:   c.Query("testament"),
+	}
+	var err error
+	if filter.Page, filter.PerPage, err = helper.ParsePage(c, model.DefaultPerPage); err != nil {
+		return helper.NewResponse(fiber.StatusBadRequest, err.Error(), nil).WriteResponse(c)
+	}
+	response, err := q.concordanceUseCase.FindOccurrences(ctx, &filter)
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrFindOccurrences")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	response.Pagination = helper.NewPagination(c, filter.Page, filter.PerPage, response.Word.Verses)
+	return helper.NewResponse(fiber.StatusOK, "", response).WriteResponse(c)
+}
+
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, scriptureModel.ErrTranslationNotFound),
+		errors.Is(err, model.ErrWordNotFound),
+		errors.Is(err, model.ErrNotBuilt):
+		return fiber.StatusNotFound
+	case errors.Is(err, model.ErrWordRequired),
+		errors.Is(err, model.ErrInvalidWord),
+		errors.Is(err, model.ErrUnknownBook),
+		errors.Is(err, model.ErrInvalidTestament),
+		errors.Is(err, model.ErrInvalidLimit):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
@@ -0,0 +1,317 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/concordance/model"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+	"go.uber.org/zap"
+)
+
+type (
+	concordanceQuery struct {
+		dbRead  *pgxpool.Pool
+		dbWrite *pgxpool.Pool
+	}
+)
+
+var (
+	entryColumns = []string{"translation_id", "word", "book_id", "occurrences", "ordinals"}
+)
+
+func NewConcordanceQuery(dbRead, dbWrite *pgxpool.Pool) ConcordanceQuery {
+	return &concordanceQuery{
+		dbRead:  dbRead,
+		dbWrite: dbWrite,
+	}
+}
+
+// FindWord sums the rows of filter.Word over the books of the filter,
+// nil when the word does not occur there
+func (q *concordanceQuery) FindWord(ctx context.Context, filter *model.Filter) (*model.Word, error) {
+	ctxt := "ConcordanceQuery-FindWord"
+	params := []interface{}{filter.TranslationID, filter.Word}
+	var builder strings.Builder
+	_, _ = builder.WriteString(
+		`SELECT SUM(w."occurrences"), SUM(CARDINALITY(w."ordinals"))
+		FROM concordance_words w
+		JOIN books b ON b."id" = w."book_id"
+		WHERE w."translation_id" = $1
+			AND w."word" = $2`,
+	)
+	writeBookConditions(&builder, &params, filter)
+	var occurrences, verses *int
+	if err := q.dbRead.QueryRow(ctx, builder.String(), params...).Scan(&occurrences, &verses); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return nil, err
+	}
+	if occurrences == nil {
+		return nil, nil
+	}
+	return &model.Word{
+		Word:        filter.Word,
+		Occurrences: *occurrences,
+		Verses:      *verses,
+	}, nil
+}
+
+// FindOccurrences returns one page of the verses filter.Word occurs in,
+// in canonical order
+func (q *concordanceQuery) FindOccurrences(ctx context.Context, filter *model.Filter) ([]*scriptureModel.Verse, error) {
+	ctxt := "ConcordanceQuery-FindOccurrences"
+	params := []interface{}{filter.TranslationID, filter.Word}
+	var builder strings.Builder
+	_, _ = builder.WriteString(
+		`SELECT v."id", v."book_id", b."code", v."chapter", v."verse", v."text"
+		FROM concordance_words w
+		CROSS JOIN LATERAL UNNEST(w."ordinals") o("ordinal")
+		JOIN verses v ON v."translation_id" = w."translation_id" AND v."ordinal" = o."ordinal"
+		JOIN books b ON b."id" = w."book_id"
+		WHERE w."translation_id" = $1
+			AND w."word" = $2`,
+	)
+	writeBookConditions(&builder, &params, filter)
+	params = append(params, filter.PerPage, helper.Offset(filter.Page, filter.PerPage))
+	_, _ = builder.WriteString(fmt.Sprintf(` ORDER BY o."ordinal" LIMIT $%d OFFSET $%d`, len(params)-1, len(params)))
+	rows, err := q.dbRead.Query(ctx, builder.String(), params...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return nil, err
+	}
+	defer rows.Close()
+	var response []*scriptureModel.Verse
+	for rows.Next() {
+		var verse scriptureModel.Verse
+		if err := rows.Scan(
+			&verse.ID,
+			&verse.BookID,
+			&verse.Book,
+			&verse.Chapter,
+			&verse.Verse,
+			&verse.Text,
+		); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return nil, err
+		}
+		response = append(response, &verse)
+	}
+	return response, nil
+}
+
+// FindFrequencies returns the filter.Limit most frequent word forms of the
+// books of the filter
+func (q *concordanceQuery) FindFrequencies(ctx context.Context, filter *model.Filter) ([]*model.Word, error) {
+	ctxt := "ConcordanceQuery-FindFrequencies"
+	params := []interface{}{filter.TranslationID}
+	var builder strings.Builder
+	_, _ = builder.WriteString(
+		`SELECT w."word", SUM(w."occurrences") AS "occurrences", SUM(CARDINALITY(w."ordinals"))
+		FROM concordance_words w
+		JOIN books b ON b."id" = w."book_id"
+		WHERE w."translation_id" = $1`,
+	)
+	writeBookConditions(&builder, &params, filter)
+	params = append(params, filter.Limit)
+	_, _ = builder.WriteString(fmt.Sprintf(` GROUP BY w."word" ORDER BY "occurrences" DESC, w."word" LIMIT $%d`, len(params)))
+	rows, err := q.dbRead.Query(ctx, builder.String(), params...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return nil, err
+	}
+	defer rows.Close()
+	var response []*model.Word
+	for rows.Next() {
+		var word model.Word
+		if err := rows.Scan(&word.Word, &word.Occurrences, &word.Verses); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return nil, err
+		}
+		response = append(response, &word)
+	}
+	return response, nil
+}
+
+func (q *concordanceQuery) IsBuilt(ctx context.Context, translationID int64) (bool, error) {
+	ctxt := "ConcordanceQuery-IsBuilt"
+	var response bool
+	if err := q.dbRead.QueryRow(
+		ctx,
+		`SELECT "concordance_built_at" IS NOT NULL FROM translations WHERE "id" = $1`,
+		translationID,
+	).Scan(&response); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return false, err
+	}
+	return response, nil
+}
+
+// FindStaleTranslations returns the codes of the translations imported
+// since their concordance was last built
+func (q *concordanceQuery) FindStaleTranslations(ctx context.Context) ([]string, error) {
+	ctxt := "ConcordanceQuery-FindStaleTranslations"
+	rows, err := q.dbRead.Query(
+		ctx,
+		`SELECT "code"
+		FROM translations
+		WHERE "concordance_built_at" IS DISTINCT FROM "updated_at"
+		ORDER BY "code"`,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return nil, err
+	}
+	defer rows.Close()
+	var response []string
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return nil, err
+		}
+		response = append(response, code)
+	}
+	return response, nil
+}
+
+func (q *concordanceQuery) Begin(ctx context.Context) (pgx.Tx, error) {
+	ctxt := "ConcordanceQuery-Begin"
+	tx, err := q.dbWrite.Begin(ctx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrBegin")
+	}
+	return tx, err
+}
+
+// LockTranslation locks the translation row until tx ends, so an import
+// in progress finishes before its verses are read and a concurrent build
+// waits for this one; nil when the translation does not exist
+func (q *concordanceQuery) LockTranslation(ctx context.Context, tx pgx.Tx, code string) (*model.Build, error) {
+	ctxt := "ConcordanceQuery-LockTranslation"
+	var response model.Build
+	err := tx.QueryRow(
+		ctx,
+		`SELECT "id", "updated_at", "concordance_built_at"
+		FROM translations
+		WHERE "code" = $1
+		FOR UPDATE`,
+		strings.ToLower(code),
+	).Scan(
+		&response.TranslationID,
+		&response.UpdatedAt,
+		&response.BuiltAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return nil, err
+	}
+	return &response, nil
+}
+
+// ScanVerses calls fn with each verse of a translation in canonical order
+func (q *concordanceQuery) ScanVerses(ctx context.Context, tx pgx.Tx, translationID int64, fn func(ordinal, bookID int, text string) error) error {
+	ctxt := "ConcordanceQuery-ScanVerses"
+	rows, err := tx.Query(
+		ctx,
+		`SELECT "ordinal", "book_id", "text"
+		FROM verses
+		WHERE "translation_id" = $1
+		ORDER BY "ordinal"`,
+		translationID,
+	)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			ordinal, bookID int
+			text            string
+		)
+		if err := rows.Scan(&ordinal, &bookID, &text); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return err
+		}
+		if err := fn(ordinal, bookID, text); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrRows")
+		return err
+	}
+	return nil
+}
+
+func (q *concordanceQuery) DeleteEntries(ctx context.Context, tx pgx.Tx, translationID int64) (int64, error) {
+	ctxt := "ConcordanceQuery-DeleteEntries"
+	result, err := tx.Exec(ctx, `DELETE FROM concordance_words WHERE "translation_id" = $1`, translationID)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+func (q *concordanceQuery) CopyEntries(ctx context.Context, tx pgx.Tx, translationID int64, entries []*model.Entry) (int64, error) {
+	ctxt := "ConcordanceQuery-CopyEntries"
+	count, err := tx.CopyFrom(
+		ctx,
+		pgx.Identifier{"concordance_words"},
+		entryColumns,
+		pgx.CopyFromSlice(len(entries), func(i int) ([]interface{}, error) {
+			entry := entries[i]
+			return []interface{}{translationID, entry.Word, entry.BookID, entry.Occurrences, entry.Ordinals}, nil
+		}),
+	)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCopyFrom")
+	}
+	return count, err
+}
+
+// MarkBuilt records the concordance as built from the translation as
+// imported at builtAt, its "updated_at" when the build started
+func (q *concordanceQuery) MarkBuilt(ctx context.Context, tx pgx.Tx, translationID int64, builtAt time.Time) error {
+	ctxt := "ConcordanceQuery-MarkBuilt"
+	if _, err := tx.Exec(
+		ctx,
+		`UPDATE translations SET "concordance_built_at" = $1 WHERE "id" = $2`,
+		builtAt,
+		translationID,
+	); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+		return err
+	}
+	return nil
+}
+
+func writeBookConditions(builder *strings.Builder, params *[]interface{}, filter *model.Filter) {
+	if filter.BookID != 0 {
+		*params = append(*params, filter.BookID)
+		_, _ = fmt.Fprintf(builder, ` AND w."book_id" = $%d`, len(*params))
+	}
+	if filter.Testament != "" {
+		*params = append(*params, filter.Testament)
+		_, _ = fmt.Fprintf(builder, ` AND b."testament" = $%d`, len(*params))
+	}
+}
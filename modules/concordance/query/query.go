@@ -0,0 +1,26 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/roysitumorang/bible/modules/concordance/model"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+)
+
+type (
+	ConcordanceQuery interface {
+		FindWord(ctx context.Context, filter *model.Filter) (*model.Word, error)
+		FindOccurrences(ctx context.Context, filter *model.Filter) ([]*scriptureModel.Verse, error)
+		FindFrequencies(ctx context.Context, filter *model.Filter) ([]*model.Word, error)
+		IsBuilt(ctx context.Context, translationID int64) (bool, error)
+		FindStaleTranslations(ctx context.Context) ([]string, error)
+		Begin(ctx context.Context) (pgx.Tx, error)
+		LockTranslation(ctx context.Context, tx pgx.Tx, code string) (*model.Build, error)
+		ScanVerses(ctx context.Context, tx pgx.Tx, translationID int64, fn func(ordinal, bookID int, text string) error) error
+		DeleteEntries(ctx context.Context, tx pgx.Tx, translationID int64) (int64, error)
+		CopyEntries(ctx context.Context, tx pgx.Tx, translationID int64, entries []*model.Entry) (int64, error)
+		MarkBuilt(ctx context.Context, tx pgx.Tx, translationID int64, builtAt time.Time) error
+	}
+)
@@ -0,0 +1,314 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/roysitumorang/bible/canon"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/concordance/model"
+	"github.com/roysitumorang/bible/modules/concordance/query"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+	scriptureQuery "github.com/roysitumorang/bible/modules/scripture/query"
+	"go.uber.org/zap"
+	"golang.org/x/text/unicode/norm"
+)
+
+type (
+	concordanceUseCase struct {
+		concordanceQuery query.ConcordanceQuery
+		scriptureQuery   scriptureQuery.ScriptureQuery
+		registry         *canon.Registry
+	}
+
+	entryKey struct {
+		word   string
+		bookID int
+	}
+)
+
+func NewConcordanceUseCase(concordanceQuery query.ConcordanceQuery, scriptureQuery scriptureQuery.ScriptureQuery, registry *canon.Registry) ConcordanceUseCase {
+	return &concordanceUseCase{
+		concordanceQuery: concordanceQuery,
+		scriptureQuery:   scriptureQuery,
+		registry:         registry,
+	}
+}
+
+// LoadConfig reads CONCORDANCE_SCHEDULE, optional
+func LoadConfig() *model.Config {
+	response := model.Config{
+		Schedule: model.DefaultSchedule,
+	}
+	if schedule, ok := os.LookupEnv("CONCORDANCE_SCHEDULE"); ok && schedule != "" {
+		response.Schedule = schedule
+	}
+	return &response
+}
+
+// FindOccurrences returns the number of occurrences of filter.Word in the
+// translation, or in one book or testament of it, and one page of the
+// verses it occurs in
+func (q *concordanceUseCase) FindOccurrences(ctx context.Context, filter *model.Filter) (*model.Response, error) {
+	ctxt := "ConcordanceUseCase-FindOccurrences"
+	var err error
+	if filter.Word, err = normalize(filter.Word); err != nil {
+		return nil, err
+	}
+	translation, err := q.prepareFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	word, err := q.concordanceQuery.FindWord(ctx, filter)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindWord")
+		return nil, err
+	}
+	if word == nil {
+		return nil, q.notFound(ctx, translation.ID, model.ErrWordNotFound)
+	}
+	if filter.PerPage == 0 {
+		filter.PerPage = model.DefaultPerPage
+	}
+	filter.Page = max(filter.Page, 1)
+	verses, err := q.concordanceQuery.FindOccurrences(ctx, filter)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindOccurrences")
+		return nil, err
+	}
+	return &model.Response{
+		Translation: translation,
+		Book:        filter.Book,
+		Word:        word,
+		Verses:      verses,
+	}, nil
+}
+
+// FindFrequencies returns the filter.Limit most frequent word forms of
+// the translation, or of one book or testament of it
+func (q *concordanceUseCase) FindFrequencies(ctx context.Context, filter *model.Filter) (*model.FrequencyResponse, error) {
+	ctxt := "ConcordanceUseCase-FindFrequencies"
+	if filter.Limit == 0 {
+		filter.Limit = model.DefaultLimit
+	}
+	if filter.Limit < 1 || filter.Limit > model.MaxLimit {
+		return nil, model.ErrInvalidLimit
+	}
+	translation, err := q.prepareFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	words, err := q.concordanceQuery.FindFrequencies(ctx, filter)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindFrequencies")
+		return nil, err
+	}
+	if len(words) == 0 {
+		if err := q.notFound(ctx, translation.ID, nil); err != nil {
+			return nil, err
+		}
+		words = []*model.Word{}
+	}
+	return &model.FrequencyResponse{
+		Translation: translation,
+		Book:        filter.Book,
+		Testament:   filter.Testament,
+		Words:       words,
+	}, nil
+}
+
+// Build rebuilds the concordance of a translation, stale or not
+func (q *concordanceUseCase) Build(ctx context.Context, translationCode string) (*model.BuildReport, error) {
+	return q.build(ctx, translationCode, true)
+}
+
+// BuildStale builds the concordance of every translation imported since
+// its concordance was last built, as scheduled after imports
+func (q *concordanceUseCase) BuildStale(ctx context.Context) ([]*model.BuildReport, error) {
+	ctxt := "ConcordanceUseCase-BuildStale"
+	codes, err := q.concordanceQuery.FindStaleTranslations(ctx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindStaleTranslations")
+		return nil, err
+	}
+	var response []*model.BuildReport
+	for _, code := range codes {
+		report, err := q.build(ctx, code, false)
+		if err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrBuild")
+			return response, err
+		}
+		if report != nil {
+			response = append(response, report)
+		}
+	}
+	return response, nil
+}
+
+// build replaces the concordance of a translation in a single transaction,
+// skipping it (nil report) unless force or stale once its row is locked
+func (q *concordanceUseCase) build(ctx context.Context, translationCode string, force bool) (*model.BuildReport, error) {
+	ctxt := "ConcordanceUseCase-build"
+	now := time.Now()
+	tx, err := q.concordanceQuery.Begin(ctx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrBegin")
+		return nil, err
+	}
+	defer func() {
+		if errRollback := tx.Rollback(ctx); errRollback != nil && !errors.Is(errRollback, pgx.ErrTxClosed) {
+			helper.Capture(ctx, zap.ErrorLevel, errRollback, ctxt, "ErrRollback")
+		}
+	}()
+	build, err := q.concordanceQuery.LockTranslation(ctx, tx, translationCode)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLockTranslation")
+		return nil, err
+	}
+	if build == nil {
+		return nil, fmt.Errorf("%s: %w", translationCode, scriptureModel.ErrTranslationNotFound)
+	}
+	if !force && build.BuiltAt != nil && build.BuiltAt.Equal(build.UpdatedAt) {
+		return nil, nil
+	}
+	response := model.BuildReport{
+		Translation: strings.ToLower(translationCode),
+	}
+	var (
+		entries []*model.Entry
+		index   = map[entryKey]*model.Entry{}
+		forms   = map[string]bool{}
+	)
+	if err := q.concordanceQuery.ScanVerses(ctx, tx, build.TranslationID, func(ordinal, bookID int, text string) error {
+		response.Verses++
+		for _, word := range words(text) {
+			key := entryKey{word: word, bookID: bookID}
+			entry, ok := index[key]
+			if !ok {
+				entry = &model.Entry{Word: word, BookID: bookID}
+				index[key] = entry
+				entries = append(entries, entry)
+				forms[word] = true
+			}
+			entry.Occurrences++
+			if n := len(entry.Ordinals); n == 0 || entry.Ordinals[n-1] != int32(ordinal) {
+				entry.Ordinals = append(entry.Ordinals, int32(ordinal))
+			}
+			response.Occurrences++
+		}
+		return nil
+	}); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScanVerses")
+		return nil, err
+	}
+	if _, err := q.concordanceQuery.DeleteEntries(ctx, tx, build.TranslationID); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrDeleteEntries")
+		return nil, err
+	}
+	if len(entries) > 0 {
+		if _, err := q.concordanceQuery.CopyEntries(ctx, tx, build.TranslationID, entries); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCopyEntries")
+			return nil, err
+		}
+	}
+	if err := q.concordanceQuery.MarkBuilt(ctx, tx, build.TranslationID, build.UpdatedAt); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrMarkBuilt")
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCommit")
+		return nil, err
+	}
+	response.Words = len(forms)
+	response.Duration = time.Since(now)
+	return &response, nil
+}
+
+// prepareFilter resolves the translation, book and testament of filter
+func (q *concordanceUseCase) prepareFilter(ctx context.Context, filter *model.Filter) (*scriptureModel.Translation, error) {
+	ctxt := "ConcordanceUseCase-prepareFilter"
+	if filter.Book != "" {
+		book, ok := q.registry.ResolveBook(filter.Book)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", model.ErrUnknownBook, filter.Book)
+		}
+		filter.Book, filter.BookID = book.Code, book.Order
+	}
+	filter.Testament = strings.ToUpper(filter.Testament)
+	if filter.Testament != "" && filter.Testament != canon.TestamentOld && filter.Testament != canon.TestamentNew {
+		return nil, model.ErrInvalidTestament
+	}
+	translation, err := q.scriptureQuery.FindTranslationByCode(ctx, filter.Translation)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindTranslationByCode")
+		return nil, err
+	}
+	filter.TranslationID = translation.ID
+	return translation, nil
+}
+
+// notFound tells an empty result of a concordance not built yet apart
+// from err
+func (q *concordanceUseCase) notFound(ctx context.Context, translationID int64, err error) error {
+	ctxt := "ConcordanceUseCase-notFound"
+	built, errBuilt := q.concordanceQuery.IsBuilt(ctx, translationID)
+	if errBuilt != nil {
+		helper.Capture(ctx, zap.ErrorLevel, errBuilt, ctxt, "ErrIsBuilt")
+		return errBuilt
+	}
+	if !built {
+		return model.ErrNotBuilt
+	}
+	return err
+}
+
+// normalize turns a search term into the word form it is stored under
+func normalize(word string) (string, error) {
+	if strings.TrimSpace(word) == "" {
+		return "", model.ErrWordRequired
+	}
+	forms := words(word)
+	if len(forms) != 1 {
+		return "", model.ErrInvalidWord
+	}
+	return forms[0], nil
+}
+
+// words splits text into normalized word forms: lower case, without
+// diacritics, vowel points or cantillation marks, and with a final sigma
+// written as any other; an apostrophe between letters ("Lord's") belongs
+// to the word. What is left is recomposed, keeping Hangul syllables whole
+func words(text string) []string {
+	runes := []rune(norm.NFD.String(text))
+	var (
+		response []string
+		builder  strings.Builder
+	)
+	flush := func() {
+		if builder.Len() > 0 {
+			response = append(response, norm.NFC.String(builder.String()))
+			builder.Reset()
+		}
+	}
+	for i, r := range runes {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+		case r == 'ς':
+			_, _ = builder.WriteRune('σ')
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			_, _ = builder.WriteRune(unicode.ToLower(r))
+		case (r == '\'' || r == '’') && builder.Len() > 0 && i+1 < len(runes) && unicode.IsLetter(runes[i+1]):
+			_, _ = builder.WriteRune('\'')
+		default:
+			flush()
+		}
+	}
+	flush()
+	return response
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	for _, tc := range []struct {
+		name, text string
+		want       []string
+	}{
+		{"punctuation", "In the beginning, God created (the heaven).", []string{"in", "the", "beginning", "god", "created", "the", "heaven"}},
+		{"digits", "Psalm 119 has 176 verses", []string{"psalm", "119", "has", "176", "verses"}},
+		{"accents", "Éloa, naïve", []string{"eloa", "naive"}},
+		{"final sigma", "Ἐν ἀρχῇ ἦν ὁ λόγος", []string{"εν", "αρχη", "ην", "ο", "λογοσ"}},
+		{"capital sigma", "ΛΟΓΟΣ", []string{"λογοσ"}},
+		{"Hebrew points", "בְּרֵאשִׁית בָּרָא", []string{"בראשית", "ברא"}},
+		{"Hebrew maqaf", "אֶת־הָאָרֶץ", []string{"את", "הארץ"}},
+		{"inner apostrophes", "don't, God’s", []string{"don't", "god's"}},
+		{"outer apostrophes", "'tis the brethren' ’twas", []string{"tis", "the", "brethren", "twas"}},
+		{"Hangul recomposition", "태초에 하나님이", []string{"태초에", "하나님이"}},
+		{"empty", " , ", nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := words(tc.text); !slices.Equal(got, tc.want) {
+				t.Errorf("words(%q) = %q, want %q", tc.text, got, tc.want)
+			}
+		})
+	}
+}
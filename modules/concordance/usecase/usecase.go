@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/modules/concordance/model"
+)
+
+type (
+	ConcordanceUseCase interface {
+		FindOccurrences(ctx context.Context, filter *model.Filter) (*model.Response, error)
+		FindFrequencies(ctx context.Context, filter *model.Filter) (*model.FrequencyResponse, error)
+		Build(ctx context.Context, translationCode string) (*model.BuildReport, error)
+		BuildStale(ctx context.Context) ([]*model.BuildReport, error)
+	}
+)
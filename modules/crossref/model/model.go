@@ -0,0 +1,69 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+)
+
+type (
+	// CrossReference is a verse range related to the requested passage;
+	// Votes sums the votes of the links from every verse of the passage
+	CrossReference struct {
+		Reference    string                  `json:"reference"`
+		StartOrdinal int                     `json:"-"`
+		EndOrdinal   int                     `json:"-"`
+		Votes        int                     `json:"votes"`
+		Verses       []*scriptureModel.Verse `json:"verses"`
+	}
+
+	Response struct {
+		Passage         *scriptureModel.Passage `json:"passage"`
+		CrossReferences []*CrossReference       `json:"cross_references"`
+	}
+
+	Request struct {
+		Reference   string
+		Translation string
+		Limit       int
+	}
+
+	// Filter selects the links leaving any of the ordinal ranges
+	// StartOrdinals[i]-EndOrdinals[i]
+	Filter struct {
+		StartOrdinals []int
+		EndOrdinals   []int
+		Limit         int
+	}
+
+	// Row is a link read from a dataset file
+	Row struct {
+		FromOrdinal    int
+		ToStartOrdinal int
+		ToEndOrdinal   int
+		ToReference    string
+		Votes          int
+	}
+
+	ImportReport struct {
+		Rows    int `json:"rows"`
+		Skipped int `json:"skipped"`
+		// links from a range rather than a verse, skipped: looking up a
+		// verse of the range would miss them
+		RangedSources int           `json:"ranged_sources"`
+		Duration      time.Duration `json:"duration"`
+	}
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+var (
+	ErrReferenceRequired   = errors.New("ref: required")
+	ErrTranslationRequired = errors.New("translation: required")
+	ErrInvalidLimit        = errors.New("limit: expected 1 to 100")
+	ErrNoRows              = errors.New("no cross references found")
+)
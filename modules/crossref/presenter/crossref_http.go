@@ -0,0 +1,62 @@
+package presenter
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/crossref/model"
+	"github.com/roysitumorang/bible/modules/crossref/usecase"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+	"github.com/roysitumorang/bible/reference"
+	"go.uber.org/zap"
+)
+
+type (
+	CrossRefHTTPHandler struct {
+		crossRefUseCase usecase.CrossRefUseCase
+	}
+)
+
+func NewCrossRefHTTPHandler(crossRefUseCase usecase.CrossRefUseCase) *CrossRefHTTPHandler {
+	return &CrossRefHTTPHandler{
+		crossRefUseCase: crossRefUseCase,
+	}
+}
+
+func (q *CrossRefHTTPHandler) Mount(r fiber.Router) {
+	r.Get("", q.FindCrossReferences)
+}
+
+// FindCrossReferences handles GET /v1/cross-references?ref=John+3:16&translation=kjv&limit=20
+func (q *CrossRefHTTPHandler) FindCrossReferences(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "CrossRefHTTPHandler-FindCrossReferences"
+	request := model.Request{
+		Reference:   c.Query("ref"),
+		Translation: c.Query("translation"),
+		Limit:       c.QueryInt("limit", model.DefaultLimit),
+	}
+	response, err := q.crossRefUseCase.FindCrossReferences(ctx, &request)
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrFindCrossReferences")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(fiber.StatusOK, "", response).WriteResponse(c)
+}
+
+func statusCode(err error) int {
+	var parseErr *reference.ParseError
+	switch {
+	case errors.Is(err, scriptureModel.ErrTranslationNotFound),
+		errors.Is(err, scriptureModel.ErrVerseNotFound):
+		return fiber.StatusNotFound
+	case errors.As(err, &parseErr),
+		errors.Is(err, scriptureModel.ErrPassageTooLong),
+		errors.Is(err, model.ErrReferenceRequired),
+		errors.Is(err, model.ErrTranslationRequired),
+		errors.Is(err, model.ErrInvalidLimit):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
@@ -0,0 +1,107 @@
+package query
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/crossref/model"
+	"go.uber.org/zap"
+)
+
+type (
+	crossRefQuery struct {
+		dbRead  *pgxpool.Pool
+		dbWrite *pgxpool.Pool
+	}
+)
+
+var (
+	crossReferenceColumns = []string{"from_ordinal", "to_start_ordinal", "to_end_ordinal", "to_reference", "votes"}
+)
+
+func NewCrossRefQuery(dbRead, dbWrite *pgxpool.Pool) CrossRefQuery {
+	return &crossRefQuery{
+		dbRead:  dbRead,
+		dbWrite: dbWrite,
+	}
+}
+
+// FindCrossReferences ranks the targets of the links leaving the filter
+// ranges by their summed votes, canonical order breaking ties
+func (q *crossRefQuery) FindCrossReferences(ctx context.Context, filter *model.Filter) ([]*model.CrossReference, error) {
+	ctxt := "CrossRefQuery-FindCrossReferences"
+	rows, err := q.dbRead.Query(
+		ctx,
+		`SELECT x."to_reference", x."to_start_ordinal", x."to_end_ordinal", SUM(x."votes")
+		FROM cross_references x
+		JOIN UNNEST($1::integer[], $2::integer[]) r("start_ordinal", "end_ordinal") ON x."from_ordinal" BETWEEN r."start_ordinal" AND r."end_ordinal"
+		GROUP BY x."to_reference", x."to_start_ordinal", x."to_end_ordinal"
+		ORDER BY 2 DESC, x."to_start_ordinal"
+		LIMIT $3`,
+		filter.StartOrdinals,
+		filter.EndOrdinals,
+		filter.Limit,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return nil, err
+	}
+	defer rows.Close()
+	var response []*model.CrossReference
+	for rows.Next() {
+		var crossReference model.CrossReference
+		if err := rows.Scan(
+			&crossReference.Reference,
+			&crossReference.StartOrdinal,
+			&crossReference.EndOrdinal,
+			&crossReference.Votes,
+		); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return nil, err
+		}
+		response = append(response, &crossReference)
+	}
+	return response, nil
+}
+
+func (q *crossRefQuery) Begin(ctx context.Context) (pgx.Tx, error) {
+	ctxt := "CrossRefQuery-Begin"
+	tx, err := q.dbWrite.Begin(ctx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrBegin")
+	}
+	return tx, err
+}
+
+func (q *crossRefQuery) DeleteCrossReferences(ctx context.Context, tx pgx.Tx) (int64, error) {
+	ctxt := "CrossRefQuery-DeleteCrossReferences"
+	result, err := tx.Exec(ctx, `DELETE FROM cross_references`)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+func (q *crossRefQuery) CopyCrossReferences(ctx context.Context, tx pgx.Tx, rows []*model.Row) (int64, error) {
+	ctxt := "CrossRefQuery-CopyCrossReferences"
+	count, err := tx.CopyFrom(
+		ctx,
+		pgx.Identifier{"cross_references"},
+		crossReferenceColumns,
+		pgx.CopyFromSlice(len(rows), func(i int) ([]interface{}, error) {
+			row := rows[i]
+			return []interface{}{row.FromOrdinal, row.ToStartOrdinal, row.ToEndOrdinal, row.ToReference, row.Votes}, nil
+		}),
+	)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCopyFrom")
+	}
+	return count, err
+}
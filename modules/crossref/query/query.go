@@ -0,0 +1,17 @@
+package query
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/roysitumorang/bible/modules/crossref/model"
+)
+
+type (
+	CrossRefQuery interface {
+		FindCrossReferences(ctx context.Context, filter *model.Filter) ([]*model.CrossReference, error)
+		Begin(ctx context.Context) (pgx.Tx, error)
+		DeleteCrossReferences(ctx context.Context, tx pgx.Tx) (int64, error)
+		CopyCrossReferences(ctx context.Context, tx pgx.Tx, rows []*model.Row) (int64, error)
+	}
+)
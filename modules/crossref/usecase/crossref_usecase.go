@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/roysitumorang/bible/canon"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/crossref/model"
+	"github.com/roysitumorang/bible/modules/crossref/query"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+	scriptureUseCase "github.com/roysitumorang/bible/modules/scripture/usecase"
+	"github.com/roysitumorang/bible/reference"
+	"github.com/roysitumorang/bible/versification"
+	"go.uber.org/zap"
+)
+
+type (
+	crossRefUseCase struct {
+		crossRefQuery    query.CrossRefQuery
+		scriptureUseCase scriptureUseCase.ScriptureUseCase
+		registry         *canon.Registry
+		parser           *reference.Parser
+	}
+
+	// span is a parsed reference as the ordinals bounding it
+	span struct {
+		reference string
+		start     int
+		end       int
+	}
+)
+
+func NewCrossRefUseCase(
+	crossRefQuery query.CrossRefQuery,
+	scriptureUseCase scriptureUseCase.ScriptureUseCase,
+	registry *canon.Registry,
+) CrossRefUseCase {
+	return &crossRefUseCase{
+		crossRefQuery:    crossRefQuery,
+		scriptureUseCase: scriptureUseCase,
+		registry:         registry,
+		parser:           reference.NewParser(registry),
+	}
+}
+
+// FindCrossReferences returns the passage of request.Reference and the
+// most voted ranges it links to, both in request.Translation; references
+// are numbered in the standard versification, as the datasets are
+func (q *crossRefUseCase) FindCrossReferences(ctx context.Context, request *model.Request) (*model.Response, error) {
+	ctxt := "CrossRefUseCase-FindCrossReferences"
+	if strings.TrimSpace(request.Reference) == "" {
+		return nil, model.ErrReferenceRequired
+	}
+	if request.Translation == "" {
+		return nil, model.ErrTranslationRequired
+	}
+	if request.Limit == 0 {
+		request.Limit = model.DefaultLimit
+	}
+	if request.Limit < 1 || request.Limit > model.MaxLimit {
+		return nil, model.ErrInvalidLimit
+	}
+	ranges, err := q.parser.Parse(request.Reference)
+	if err != nil {
+		return nil, err
+	}
+	filter := model.Filter{
+		StartOrdinals: make([]int, len(ranges)),
+		EndOrdinals:   make([]int, len(ranges)),
+		Limit:         request.Limit,
+	}
+	for i, r := range ranges {
+		filter.StartOrdinals[i], filter.EndOrdinals[i] = scriptureModel.RangeOrdinals(q.registry, r)
+	}
+	passage, err := q.scriptureUseCase.FindPassage(ctx, request.Translation, request.Reference, versification.Standard)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindPassage")
+		return nil, err
+	}
+	crossReferences, err := q.crossRefQuery.FindCrossReferences(ctx, &filter)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindCrossReferences")
+		return nil, err
+	}
+	targets := make([]versification.Interval, len(crossReferences))
+	for i, crossReference := range crossReferences {
+		targets[i] = versification.Interval{Start: crossReference.StartOrdinal, End: crossReference.EndOrdinal}
+	}
+	verses, err := q.scriptureUseCase.FindVersesInRanges(ctx, request.Translation, versification.Standard, targets)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindVersesInRanges")
+		return nil, err
+	}
+	for i, crossReference := range crossReferences {
+		// a target the translation lacks (e.g. an Old Testament only
+		// text) is still listed, without verses
+		if len(verses[i]) > 0 {
+			crossReference.Verses = verses[i]
+		}
+	}
+	return &model.Response{
+		Passage:         passage,
+		CrossReferences: crossReferences,
+	}, nil
+}
+
+// Import replaces the stored links with the ones of a tab separated
+// dataset: from, to and optional votes per line, as in the OpenBible.info
+// export ("Gen.1.1	John.1.1-John.1.3	369"). Header and comment lines
+// are ignored, links to references the registry cannot resolve skipped.
+func (q *crossRefUseCase) Import(ctx context.Context, r io.Reader) (*model.ImportReport, error) {
+	ctxt := "CrossRefUseCase-Import"
+	now := time.Now()
+	var (
+		response model.ImportReport
+		rows     []*model.Row
+		spans    = map[string]*span{}
+		// the same link listed twice adds up its votes
+		index = map[[3]int]*model.Row{}
+	)
+	parse := func(ref string) *span {
+		result, ok := spans[ref]
+		if !ok {
+			if ranges, err := q.parser.Parse(ref); err == nil && len(ranges) == 1 {
+				result = &span{reference: ranges[0].OSIS()}
+				result.start, result.end = scriptureModel.RangeOrdinals(q.registry, ranges[0])
+			}
+			spans[ref] = result
+		}
+		return result
+	}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(strings.ToLower(text), "from") {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: expected from and to references", line)
+		}
+		var votes int
+		if len(fields) > 2 && strings.TrimSpace(fields[2]) != "" {
+			var err error
+			if votes, err = strconv.Atoi(strings.TrimSpace(fields[2])); err != nil {
+				return nil, fmt.Errorf("line %d: invalid votes %q", line, fields[2])
+			}
+		}
+		from, to := parse(strings.TrimSpace(fields[0])), parse(strings.TrimSpace(fields[1]))
+		if from == nil || to == nil {
+			response.Skipped++
+			continue
+		}
+		// a link is looked up by the verse it leaves
+		if from.start != from.end {
+			response.RangedSources++
+			continue
+		}
+		key := [3]int{from.start, to.start, to.end}
+		if row, ok := index[key]; ok {
+			row.Votes += votes
+			continue
+		}
+		row := &model.Row{
+			FromOrdinal:    from.start,
+			ToStartOrdinal: to.start,
+			ToEndOrdinal:   to.end,
+			ToReference:    to.reference,
+			Votes:          votes,
+		}
+		index[key] = row
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, model.ErrNoRows
+	}
+	tx, err := q.crossRefQuery.Begin(ctx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrBegin")
+		return nil, err
+	}
+	defer func() {
+		if errRollback := tx.Rollback(ctx); errRollback != nil && !errors.Is(errRollback, pgx.ErrTxClosed) {
+			helper.Capture(ctx, zap.ErrorLevel, errRollback, ctxt, "ErrRollback")
+		}
+	}()
+	if _, err := q.crossRefQuery.DeleteCrossReferences(ctx, tx); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrDeleteCrossReferences")
+		return nil, err
+	}
+	count, err := q.crossRefQuery.CopyCrossReferences(ctx, tx, rows)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCopyCrossReferences")
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCommit")
+		return nil, err
+	}
+	response.Rows = int(count)
+	response.Duration = time.Since(now)
+	return &response, nil
+}
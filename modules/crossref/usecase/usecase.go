@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+	"io"
+
+	"github.com/roysitumorang/bible/modules/crossref/model"
+)
+
+type (
+	CrossRefUseCase interface {
+		FindCrossReferences(ctx context.Context, request *model.Request) (*model.Response, error)
+		Import(ctx context.Context, r io.Reader) (*model.ImportReport, error)
+	}
+)
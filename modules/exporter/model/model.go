@@ -0,0 +1,31 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+type (
+	Request struct {
+		Translation string
+		Reference   string
+		Format      string
+	}
+
+	Report struct {
+		Translation string        `json:"translation"`
+		Format      string        `json:"format"`
+		Verses      int           `json:"verses"`
+		Duration    time.Duration `json:"duration"`
+	}
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+	FormatOSIS = "osis"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown export format, expected json, csv or osis")
+)
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/roysitumorang/bible/canon"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/exporter/model"
+	"github.com/roysitumorang/bible/modules/exporter/writer"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+	scriptureQuery "github.com/roysitumorang/bible/modules/scripture/query"
+	"github.com/roysitumorang/bible/reference"
+	"go.uber.org/zap"
+)
+
+type (
+	exporterUseCase struct {
+		scriptureQuery scriptureQuery.ScriptureQuery
+		registry       *canon.Registry
+		parser         *reference.Parser
+	}
+)
+
+func NewExporterUseCase(scriptureQuery scriptureQuery.ScriptureQuery, registry *canon.Registry) ExporterUseCase {
+	return &exporterUseCase{
+		scriptureQuery: scriptureQuery,
+		registry:       registry,
+		parser:         reference.NewParser(registry),
+	}
+}
+
+// Export writes the whole translation, or the ranges of request.Reference,
+// to w in canonical order, streaming rows from the read pool
+func (q *exporterUseCase) Export(ctx context.Context, request *model.Request, w io.Writer) (*model.Report, error) {
+	ctxt := "ExporterUseCase-Export"
+	now := time.Now()
+	out, err := writer.New(request.Format, w)
+	if err != nil {
+		return nil, err
+	}
+	translation, err := q.scriptureQuery.FindTranslationByCode(ctx, request.Translation)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindTranslationByCode")
+		return nil, err
+	}
+	filters := []*scriptureModel.VerseFilter{{
+		TranslationID: translation.ID,
+		EndOrdinal:    scriptureModel.MaxOrdinal,
+	}}
+	if request.Reference != "" {
+		ranges, err := q.parser.Parse(request.Reference)
+		if err != nil {
+			return nil, err
+		}
+		filters = make([]*scriptureModel.VerseFilter, len(ranges))
+		for i, r := range ranges {
+			filters[i] = &scriptureModel.VerseFilter{TranslationID: translation.ID}
+			filters[i].StartOrdinal, filters[i].EndOrdinal = scriptureModel.RangeOrdinals(q.registry, r)
+		}
+	}
+	if err := out.Begin(translation); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrBegin")
+		return nil, err
+	}
+	response := model.Report{
+		Translation: translation.Code,
+		Format:      request.Format,
+	}
+	for _, filter := range filters {
+		if err := q.scriptureQuery.StreamVerses(ctx, filter, func(verse *scriptureModel.Verse) error {
+			response.Verses++
+			return out.Write(verse)
+		}); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrStreamVerses")
+			return nil, err
+		}
+	}
+	if err := out.End(); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrEnd")
+		return nil, err
+	}
+	response.Duration = time.Since(now)
+	return &response, nil
+}
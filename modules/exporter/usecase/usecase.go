@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+	"io"
+
+	"github.com/roysitumorang/bible/modules/exporter/model"
+)
+
+type (
+	ExporterUseCase interface {
+		Export(ctx context.Context, request *model.Request, w io.Writer) (*model.Report, error)
+	}
+)
@@ -0,0 +1,41 @@
+package writer
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+)
+
+type (
+	// CSVWriter writes book,chapter,verse,text rows with a header, the
+	// layout the CSV importer reads by default
+	CSVWriter struct {
+		w *csv.Writer
+	}
+)
+
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{
+		w: csv.NewWriter(w),
+	}
+}
+
+func (q *CSVWriter) Begin(_ *scriptureModel.Translation) error {
+	return q.w.Write([]string{"book", "chapter", "verse", "text"})
+}
+
+func (q *CSVWriter) Write(verse *scriptureModel.Verse) error {
+	return q.w.Write([]string{
+		verse.Book,
+		strconv.Itoa(verse.Chapter),
+		strconv.Itoa(verse.Verse),
+		verse.Text,
+	})
+}
+
+func (q *CSVWriter) End() error {
+	q.w.Flush()
+	return q.w.Error()
+}
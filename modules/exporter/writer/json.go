@@ -0,0 +1,60 @@
+package writer
+
+import (
+	"io"
+
+	"github.com/goccy/go-json"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+)
+
+type (
+	// JSONWriter writes {"translation": {…}, "verses": […]} one verse at a
+	// time instead of marshaling the whole document at once
+	JSONWriter struct {
+		w     io.Writer
+		count int
+	}
+)
+
+func NewJSONWriter(w io.Writer) *JSONWriter {
+	return &JSONWriter{
+		w: w,
+	}
+}
+
+func (q *JSONWriter) Begin(translation *scriptureModel.Translation) error {
+	header, err := json.Marshal(translation)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(q.w, `{"translation":`); err != nil {
+		return err
+	}
+	if _, err := q.w.Write(header); err != nil {
+		return err
+	}
+	_, err = io.WriteString(q.w, `,"verses":[`)
+	return err
+}
+
+func (q *JSONWriter) Write(verse *scriptureModel.Verse) error {
+	content, err := json.Marshal(verse)
+	if err != nil {
+		return err
+	}
+	if q.count > 0 {
+		if _, err := io.WriteString(q.w, ",\n"); err != nil {
+			return err
+		}
+	} else if _, err := io.WriteString(q.w, "\n"); err != nil {
+		return err
+	}
+	q.count++
+	_, err = q.w.Write(content)
+	return err
+}
+
+func (q *JSONWriter) End() error {
+	_, err := io.WriteString(q.w, "\n]}\n")
+	return err
+}
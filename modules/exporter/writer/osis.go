@@ -0,0 +1,118 @@
+package writer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+)
+
+type (
+	// OSISWriter writes an OSIS 2.1 document with container verses inside
+	// book divs and chapters
+	OSISWriter struct {
+		w       io.Writer
+		book    string
+		chapter int
+	}
+)
+
+func NewOSISWriter(w io.Writer) *OSISWriter {
+	return &OSISWriter{
+		w: w,
+	}
+}
+
+func (q *OSISWriter) Begin(translation *scriptureModel.Translation) error {
+	work := strings.ToUpper(translation.Code)
+	if _, err := fmt.Fprintf(
+		q.w,
+		`<?xml version="1.0" encoding="UTF-8"?>
+<osis xmlns="http://www.bibletechnologies.net/2003/OSIS/namespace" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="http://www.bibletechnologies.net/2003/OSIS/namespace http://www.bibletechnologies.net/osisCore.2.1.1.xsd">
+<osisText osisIDWork="%s" osisRefWork="Bible" xml:lang="%s" canonical="true">
+<header>
+<work osisWork="%s">
+<title>%s</title>
+<language type="IETF">%s</language>
+`,
+		escape(work),
+		escape(translation.Language),
+		escape(work),
+		escape(translation.Name),
+		escape(translation.Language),
+	); err != nil {
+		return err
+	}
+	if translation.Description != nil {
+		if _, err := fmt.Fprintf(q.w, "<description>%s</description>\n", escape(*translation.Description)); err != nil {
+			return err
+		}
+	}
+	if translation.License != nil {
+		if _, err := fmt.Fprintf(q.w, "<rights>%s</rights>\n", escape(*translation.License)); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(q.w, "<refSystem>Bible</refSystem>\n</work>\n</header>\n")
+	return err
+}
+
+func (q *OSISWriter) Write(verse *scriptureModel.Verse) error {
+	if verse.Book != q.book {
+		if err := q.closeBook(); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(q.w, "<div type=\"book\" osisID=\"%s\" canonical=\"true\">\n", verse.Book); err != nil {
+			return err
+		}
+		q.book = verse.Book
+	}
+	if verse.Chapter != q.chapter {
+		if err := q.closeChapter(); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(q.w, "<chapter osisID=\"%s.%d\">\n", verse.Book, verse.Chapter); err != nil {
+			return err
+		}
+		q.chapter = verse.Chapter
+	}
+	_, err := fmt.Fprintf(q.w, "<verse osisID=\"%[1]s.%[2]d.%[3]d\">%[4]s</verse>\n", verse.Book, verse.Chapter, verse.Verse, escape(verse.Text))
+	return err
+}
+
+func (q *OSISWriter) End() error {
+	if err := q.closeBook(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(q.w, "</osisText>\n</osis>\n")
+	return err
+}
+
+func (q *OSISWriter) closeChapter() error {
+	if q.chapter == 0 {
+		return nil
+	}
+	q.chapter = 0
+	_, err := io.WriteString(q.w, "</chapter>\n")
+	return err
+}
+
+func (q *OSISWriter) closeBook() error {
+	if q.book == "" {
+		return nil
+	}
+	if err := q.closeChapter(); err != nil {
+		return err
+	}
+	q.book = ""
+	_, err := io.WriteString(q.w, "</div>\n")
+	return err
+}
+
+func escape(text string) string {
+	var builder strings.Builder
+	_ = xml.EscapeText(&builder, []byte(text))
+	return builder.String()
+}
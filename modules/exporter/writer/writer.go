@@ -0,0 +1,30 @@
+package writer
+
+import (
+	"io"
+
+	"github.com/roysitumorang/bible/modules/exporter/model"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+)
+
+type (
+	// Writer serializes a translation verse by verse; Begin is called once
+	// before the first verse and End once after the last
+	Writer interface {
+		Begin(translation *scriptureModel.Translation) error
+		Write(verse *scriptureModel.Verse) error
+		End() error
+	}
+)
+
+func New(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case model.FormatJSON:
+		return NewJSONWriter(w), nil
+	case model.FormatCSV:
+		return NewCSVWriter(w), nil
+	case model.FormatOSIS:
+		return NewOSISWriter(w), nil
+	}
+	return nil, model.ErrUnknownFormat
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/roysitumorang/bible/models"
+)
+
+type (
+	Translation struct {
+		ID          int64
+		Code        string
+		Name        string
+		Language    string
+		Description *string
+		License     *string
+		// PostgreSQL text search configuration, derived from Language
+		// when empty; unknown configurations fall back to 'simple'
+		TextSearchConfig string
+		// versification.Registry scheme code, versification.Standard
+		// when empty
+		Versification string
+	}
+
+	// Verse is a verse as read from a source file; Book is whatever the
+	// file calls the book and gets resolved through the book registry
+	// into BookID. Content is only set by formats that carry structure,
+	// Words by tagged texts.
+	Verse struct {
+		Book      string
+		BookID    int
+		ChapterID int64
+		Chapter   int
+		Verse     int
+		Text      string
+		Content   []*models.Segment
+		Words     []*Word
+	}
+
+	// Word is a word (or phrase) of a verse tagged with the normalized
+	// Strong's numbers of the lemmas it translates; Position numbers the
+	// tagged words of the verse from 1
+	Word struct {
+		Position int
+		Text     string
+		Strongs  []string
+	}
+
+	Chapter struct {
+		ID     int64
+		BookID int
+		Number int
+	}
+
+	Request struct {
+		Translation Translation
+		Overwrite   bool
+	}
+
+	BookReport struct {
+		Book     string `json:"book"`
+		Chapters int    `json:"chapters"`
+		Verses   int    `json:"verses"`
+	}
+
+	Report struct {
+		Translation string        `json:"translation"`
+		Replaced    bool          `json:"replaced"`
+		Books       []*BookReport `json:"books"`
+		Verses      int           `json:"verses"`
+		// Strong's tags stored, zero for untagged translations
+		Tags     int           `json:"tags"`
+		Duration time.Duration `json:"duration"`
+	}
+)
+
+const (
+	// verses are flushed to the database in batches of this size
+	BatchSize = 1000
+)
+
+var (
+	TextSearchConfigs = map[string]string{
+		"en":  "english",
+		"eng": "english",
+		"id":  "indonesian",
+		"ind": "indonesian",
+	}
+
+	ErrTranslationExists  = errors.New("translation already exists, use --overwrite to replace it")
+	ErrTranslationInvalid = errors.New("translation code, name and language are required")
+	ErrNoVerses           = errors.New("no verses found")
+)
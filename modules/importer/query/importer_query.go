@@ -0,0 +1,210 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/importer/model"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+	"go.uber.org/zap"
+)
+
+type (
+	importerQuery struct {
+		dbWrite *pgxpool.Pool
+	}
+)
+
+var (
+	verseColumns = []string{"translation_id", "chapter_id", "book_id", "chapter", "verse", "text", "content"}
+	wordColumns  = []string{"translation_id", "ordinal", "position", "word", "strong"}
+)
+
+func NewImporterQuery(dbWrite *pgxpool.Pool) ImporterQuery {
+	return &importerQuery{
+		dbWrite: dbWrite,
+	}
+}
+
+func (q *importerQuery) Begin(ctx context.Context) (pgx.Tx, error) {
+	ctxt := "ImporterQuery-Begin"
+	tx, err := q.dbWrite.Begin(ctx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrBegin")
+	}
+	return tx, err
+}
+
+func (q *importerQuery) FindTranslationByCode(ctx context.Context, tx pgx.Tx, code string) (*model.Translation, error) {
+	ctxt := "ImporterQuery-FindTranslationByCode"
+	var response model.Translation
+	err := tx.QueryRow(
+		ctx,
+		`SELECT "id", "code", "name", "language", "description", "license"
+		FROM translations
+		WHERE "code" = $1
+		FOR UPDATE`,
+		code,
+	).Scan(
+		&response.ID,
+		&response.Code,
+		&response.Name,
+		&response.Language,
+		&response.Description,
+		&response.License,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (q *importerQuery) CreateTranslation(ctx context.Context, tx pgx.Tx, translation *model.Translation) (int64, error) {
+	ctxt := "ImporterQuery-CreateTranslation"
+	var id int64
+	err := tx.QueryRow(
+		ctx,
+		`INSERT INTO translations ("code", "name", "language", "description", "license", "text_search_config", "versification")
+		VALUES ($1, $2, $3, $4, $5, COALESCE((SELECT "oid"::regconfig FROM pg_ts_config WHERE "cfgname" = $6), 'simple'), $7)
+		RETURNING "id"`,
+		translation.Code,
+		translation.Name,
+		translation.Language,
+		translation.Description,
+		translation.License,
+		translation.TextSearchConfig,
+		translation.Versification,
+	).Scan(&id)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+	}
+	return id, err
+}
+
+func (q *importerQuery) UpdateTranslation(ctx context.Context, tx pgx.Tx, translation *model.Translation) error {
+	ctxt := "ImporterQuery-UpdateTranslation"
+	if _, err := tx.Exec(
+		ctx,
+		`UPDATE translations SET
+			"name" = $1
+			, "language" = $2
+			, "description" = $3
+			, "license" = $4
+			, "text_search_config" = COALESCE((SELECT "oid"::regconfig FROM pg_ts_config WHERE "cfgname" = $5), 'simple')
+			, "versification" = $6
+			, "updated_at" = CURRENT_TIMESTAMP
+		WHERE "id" = $7`,
+		translation.Name,
+		translation.Language,
+		translation.Description,
+		translation.License,
+		translation.TextSearchConfig,
+		translation.Versification,
+		translation.ID,
+	); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+		return err
+	}
+	return nil
+}
+
+func (q *importerQuery) DeleteVerses(ctx context.Context, tx pgx.Tx, translationID int64) (int64, error) {
+	ctxt := "ImporterQuery-DeleteVerses"
+	result, err := tx.Exec(ctx, `DELETE FROM verses WHERE "translation_id" = $1`, translationID)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+func (q *importerQuery) FindChapters(ctx context.Context, tx pgx.Tx) ([]*model.Chapter, error) {
+	ctxt := "ImporterQuery-FindChapters"
+	rows, err := tx.Query(ctx, `SELECT "id", "book_id", "number" FROM chapters`)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return nil, err
+	}
+	defer rows.Close()
+	var response []*model.Chapter
+	for rows.Next() {
+		var chapter model.Chapter
+		if err := rows.Scan(&chapter.ID, &chapter.BookID, &chapter.Number); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return nil, err
+		}
+		response = append(response, &chapter)
+	}
+	return response, nil
+}
+
+// CreateChapter registers a chapter beyond the canonical count of a book,
+// e.g. the additions some translations carry
+func (q *importerQuery) CreateChapter(ctx context.Context, tx pgx.Tx, bookID, number int) (int64, error) {
+	ctxt := "ImporterQuery-CreateChapter"
+	var id int64
+	err := tx.QueryRow(
+		ctx,
+		`INSERT INTO chapters ("book_id", "number")
+		VALUES ($1, $2)
+		ON CONFLICT ("book_id", "number") DO UPDATE SET "number" = EXCLUDED."number"
+		RETURNING "id"`,
+		bookID,
+		number,
+	).Scan(&id)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+	}
+	return id, err
+}
+
+func (q *importerQuery) CopyVerses(ctx context.Context, tx pgx.Tx, translationID int64, verses []*model.Verse) (int64, error) {
+	ctxt := "ImporterQuery-CopyVerses"
+	count, err := tx.CopyFrom(
+		ctx,
+		pgx.Identifier{"verses"},
+		verseColumns,
+		pgx.CopyFromSlice(len(verses), func(i int) ([]interface{}, error) {
+			verse := verses[i]
+			return []interface{}{translationID, verse.ChapterID, verse.BookID, verse.Chapter, verse.Verse, verse.Text, verse.Content}, nil
+		}),
+	)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCopyFrom")
+	}
+	return count, err
+}
+
+// CopyWords stores the tagged words of verses already copied, one row per
+// Strong's number
+func (q *importerQuery) CopyWords(ctx context.Context, tx pgx.Tx, translationID int64, verses []*model.Verse) (int64, error) {
+	ctxt := "ImporterQuery-CopyWords"
+	var rows [][]interface{}
+	for _, verse := range verses {
+		ordinal := scriptureModel.Ordinal(verse.BookID, verse.Chapter, verse.Verse)
+		for _, word := range verse.Words {
+			for _, strong := range slices.Compact(slices.Sorted(slices.Values(word.Strongs))) {
+				rows = append(rows, []interface{}{translationID, ordinal, word.Position, word.Text, strong})
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	count, err := tx.CopyFrom(ctx, pgx.Identifier{"verse_words"}, wordColumns, pgx.CopyFromRows(rows))
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCopyFrom")
+	}
+	return count, err
+}
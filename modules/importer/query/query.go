@@ -0,0 +1,22 @@
+package query
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/roysitumorang/bible/modules/importer/model"
+)
+
+type (
+	ImporterQuery interface {
+		Begin(ctx context.Context) (pgx.Tx, error)
+		FindTranslationByCode(ctx context.Context, tx pgx.Tx, code string) (*model.Translation, error)
+		CreateTranslation(ctx context.Context, tx pgx.Tx, translation *model.Translation) (int64, error)
+		UpdateTranslation(ctx context.Context, tx pgx.Tx, translation *model.Translation) error
+		DeleteVerses(ctx context.Context, tx pgx.Tx, translationID int64) (int64, error)
+		FindChapters(ctx context.Context, tx pgx.Tx) ([]*model.Chapter, error)
+		CreateChapter(ctx context.Context, tx pgx.Tx, bookID, number int) (int64, error)
+		CopyVerses(ctx context.Context, tx pgx.Tx, translationID int64, verses []*model.Verse) (int64, error)
+		CopyWords(ctx context.Context, tx pgx.Tx, translationID int64, verses []*model.Verse) (int64, error)
+	}
+)
@@ -0,0 +1,154 @@
+package reader
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/roysitumorang/bible/modules/importer/model"
+)
+
+type (
+	// CSVOptions maps spreadsheet columns to verse fields. Columns names
+	// the columns in file order ("" or any other name skips a column);
+	// when empty it is taken from the header row, or defaults to
+	// book,chapter,verse,text for files without one.
+	CSVOptions struct {
+		Comma   rune
+		Header  bool
+		Columns []string
+	}
+
+	CSVReader struct {
+		r       io.Reader
+		options CSVOptions
+	}
+)
+
+const (
+	ColumnBook    = "book"
+	ColumnChapter = "chapter"
+	ColumnVerse   = "verse"
+	ColumnText    = "text"
+)
+
+var (
+	// header names understood besides the field names themselves
+	csvColumnAliases = map[string]string{
+		"kitab":     ColumnBook,
+		"book_name": ColumnBook,
+		"bookname":  ColumnBook,
+		"pasal":     ColumnChapter,
+		"ayat":      ColumnVerse,
+		"teks":      ColumnText,
+		"content":   ColumnText,
+		"isi":       ColumnText,
+	}
+	csvDefaultColumns = []string{ColumnBook, ColumnChapter, ColumnVerse, ColumnText}
+)
+
+func NewCSVReader(r io.Reader, options CSVOptions) *CSVReader {
+	if options.Comma == 0 {
+		options.Comma = ','
+	}
+	return &CSVReader{
+		r:       r,
+		options: options,
+	}
+}
+
+func (q *CSVReader) Read(ctx context.Context, translation *model.Translation, fn func(*model.Verse) error) error {
+	r := csv.NewReader(q.r)
+	r.Comma = q.options.Comma
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	r.ReuseRecord = true
+	columns := q.options.Columns
+	if q.options.Header {
+		header, err := r.Read()
+		if err != nil {
+			return fmt.Errorf("csv: header: %w", err)
+		}
+		if len(columns) == 0 {
+			columns = header
+		}
+	}
+	if len(columns) == 0 {
+		columns = csvDefaultColumns
+	}
+	indexes, err := csvIndexes(columns)
+	if err != nil {
+		return err
+	}
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("csv: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+		field := func(column string) string {
+			if i := indexes[column]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		chapter, err := strconv.Atoi(field(ColumnChapter))
+		if err != nil {
+			return fmt.Errorf("csv: line %d: invalid chapter %q", line, field(ColumnChapter))
+		}
+		verse, _, err := leadingNumber(field(ColumnVerse))
+		if err != nil {
+			return fmt.Errorf("csv: line %d: invalid verse %q", line, field(ColumnVerse))
+		}
+		if err := fn(&model.Verse{
+			Book:    field(ColumnBook),
+			Chapter: chapter,
+			Verse:   verse,
+			Text:    normalizeSpace(field(ColumnText)),
+		}); err != nil {
+			return fmt.Errorf("csv: line %d: %w", line, err)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// ParseColumns parses a column mapping flag such as "book,chapter,verse,text"
+// or "id,kitab,pasal,ayat,teks"
+func ParseColumns(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+func csvIndexes(columns []string) (map[string]int, error) {
+	indexes := map[string]int{}
+	for i, column := range columns {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		if alias, ok := csvColumnAliases[name]; ok {
+			name = alias
+		}
+		switch name {
+		case ColumnBook, ColumnChapter, ColumnVerse, ColumnText:
+			if _, ok := indexes[name]; ok {
+				return nil, fmt.Errorf("csv: column %s mapped twice", name)
+			}
+			indexes[name] = i
+		}
+	}
+	for _, name := range csvDefaultColumns {
+		if _, ok := indexes[name]; !ok {
+			return nil, fmt.Errorf("csv: no %s column in %q", name, strings.Join(columns, ","))
+		}
+	}
+	return indexes, nil
+}
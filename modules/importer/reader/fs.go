@@ -0,0 +1,62 @@
+package reader
+
+import (
+	"archive/zip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type (
+	nopCloser struct{}
+)
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// Open opens a single file, a directory or a zip archive (e.g. a USX
+// bundle) and lists, sorted, the files in it with one of the extensions
+func Open(path string, extensions ...string) (fs.FS, []string, io.Closer, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var (
+		fsys   fs.FS
+		closer io.Closer = nopCloser{}
+	)
+	switch {
+	case info.IsDir():
+		fsys = os.DirFS(path)
+	case strings.EqualFold(filepath.Ext(path), ".zip"):
+		archive, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		fsys, closer = archive, archive
+	default:
+		return os.DirFS(filepath.Dir(path)), []string{filepath.Base(path)}, closer, nil
+	}
+	var paths []string
+	if err := fs.WalkDir(fsys, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		for _, extension := range extensions {
+			if strings.EqualFold(filepath.Ext(path), extension) {
+				paths = append(paths, path)
+				break
+			}
+		}
+		return nil
+	}); err != nil {
+		_ = closer.Close()
+		return nil, nil, nil, err
+	}
+	sort.Strings(paths)
+	return fsys, paths, closer, nil
+}
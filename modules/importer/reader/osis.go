@@ -0,0 +1,230 @@
+package reader
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/roysitumorang/bible/modules/importer/model"
+	"github.com/roysitumorang/bible/strongs"
+)
+
+type (
+	OSISReader struct {
+		r io.Reader
+	}
+
+	osisState struct {
+		translation *model.Translation
+		fn          func(*model.Verse) error
+		path        []string
+		// osisID of the verse being read and, for container verses, the
+		// element depth it was opened at
+		verseID    string
+		verseDepth int
+		skipDepth  int
+		inWork     bool
+		workDone   bool
+		text       strings.Builder
+		// depth, text and Strong's numbers of the open <w lemma="strong:…">
+		wordDepth int
+		word      strings.Builder
+		strongs   []string
+		words     []*model.Word
+	}
+)
+
+// osisSkipped are elements whose text is not part of the verse text
+var osisSkipped = map[string]bool{
+	"note": true,
+}
+
+func NewOSISReader(r io.Reader) *OSISReader {
+	return &OSISReader{
+		r: r,
+	}
+}
+
+// Read handles both container (<verse osisID="Gen.1.1">…</verse>) and
+// milestone (<verse sID="…"/>…<verse eID="…"/>) verses
+func (q *OSISReader) Read(ctx context.Context, translation *model.Translation, fn func(*model.Verse) error) error {
+	decoder := xml.NewDecoder(q.r)
+	decoder.Entity = xml.HTMLEntity
+	state := &osisState{
+		translation: translation,
+		fn:          fn,
+	}
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("osis: %w", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			state.path = append(state.path, t.Name.Local)
+			if err := state.start(t); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			if err := state.end(t); err != nil {
+				return err
+			}
+			state.path = state.path[:len(state.path)-1]
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		case xml.CharData:
+			state.charData(t)
+		}
+	}
+	return state.flush()
+}
+
+func (s *osisState) start(t xml.StartElement) error {
+	depth := len(s.path)
+	switch t.Name.Local {
+	case "osisText":
+		if work := attr(t, "osisIDWork"); work != "" {
+			setIfEmpty(&s.translation.Code, strings.ToLower(work))
+		}
+		if lang := attr(t, "lang"); lang != "" {
+			setIfEmpty(&s.translation.Language, lang)
+		}
+	case "work":
+		s.inWork = !s.workDone
+	case "verse":
+		if eID := attr(t, "eID"); eID != "" {
+			return s.flush()
+		}
+		if err := s.flush(); err != nil {
+			return err
+		}
+		s.verseID = attr(t, "osisID")
+		if s.verseID == "" {
+			s.verseID = attr(t, "sID")
+		}
+		if attr(t, "sID") == "" {
+			s.verseDepth = depth
+		}
+	case "w":
+		if s.verseID == "" || s.skipDepth > 0 || s.wordDepth > 0 {
+			break
+		}
+		if s.strongs = strongs.Fields(attr(t, "lemma"), ""); len(s.strongs) > 0 {
+			s.wordDepth = depth
+			s.word.Reset()
+		}
+	default:
+		if s.skipDepth == 0 && osisSkipped[t.Name.Local] {
+			s.skipDepth = depth
+		}
+	}
+	return nil
+}
+
+func (s *osisState) end(t xml.EndElement) error {
+	depth := len(s.path)
+	switch {
+	case t.Name.Local == "work" && s.inWork:
+		s.inWork, s.workDone = false, true
+	case t.Name.Local == "verse" && s.verseDepth == depth:
+		return s.flush()
+	case s.wordDepth == depth:
+		s.words = appendWord(s.words, s.word.String(), s.strongs)
+		s.wordDepth, s.strongs = 0, nil
+	case s.skipDepth == depth:
+		s.skipDepth = 0
+	}
+	return nil
+}
+
+func (s *osisState) charData(t xml.CharData) {
+	if s.inWork && len(s.path) > 0 {
+		value := string(t)
+		switch s.path[len(s.path)-1] {
+		case "title":
+			setIfEmpty(&s.translation.Name, value)
+		case "language":
+			setIfEmpty(&s.translation.Language, value)
+		case "description":
+			setIfEmptyPtr(&s.translation.Description, value)
+		case "rights":
+			setIfEmptyPtr(&s.translation.License, value)
+		}
+		return
+	}
+	if s.verseID != "" && s.skipDepth == 0 {
+		_, _ = s.text.Write(t)
+		if s.wordDepth > 0 {
+			_, _ = s.word.Write(t)
+		}
+	}
+}
+
+// flush emits the verse being read; a verse spanning several osisIDs
+// ("Gen.1.1 Gen.1.2") is stored under the first one
+func (s *osisState) flush() error {
+	if s.verseID == "" {
+		return nil
+	}
+	id, text, words := s.verseID, normalizeSpace(s.text.String()), s.words
+	s.verseID, s.verseDepth, s.wordDepth, s.words = "", 0, 0, nil
+	s.text.Reset()
+	verse, err := parseOSISID(strings.Fields(id)[0])
+	if err != nil {
+		return err
+	}
+	verse.Text, verse.Words = text, words
+	return s.fn(verse)
+}
+
+// parseOSISID parses "Gen.1.1", "KJV:Gen.1.1" or "Gen.1.1!a"
+func parseOSISID(id string) (*model.Verse, error) {
+	ref := id
+	if i := strings.IndexByte(ref, ':'); i >= 0 {
+		ref = ref[i+1:]
+	}
+	if i := strings.IndexByte(ref, '!'); i >= 0 {
+		ref = ref[:i]
+	}
+	parts := strings.Split(ref, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("osis: invalid verse osisID %q", id)
+	}
+	chapter, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("osis: invalid verse osisID %q", id)
+	}
+	verse, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("osis: invalid verse osisID %q", id)
+	}
+	return &model.Verse{
+		Book:    parts[0],
+		Chapter: chapter,
+		Verse:   verse,
+	}, nil
+}
+
+func attr(t xml.StartElement, name string) string {
+	for _, a := range t.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func setIfEmptyPtr(field **string, value string) {
+	if *field == nil {
+		value = normalizeSpace(value)
+		*field = &value
+	}
+}
@@ -0,0 +1,40 @@
+package reader
+
+import (
+	"context"
+	"strings"
+
+	"github.com/roysitumorang/bible/modules/importer/model"
+)
+
+type (
+	// Reader streams the verses of a source file to fn in document order.
+	// Metadata found in the source fills the empty fields of translation.
+	Reader interface {
+		Read(ctx context.Context, translation *model.Translation, fn func(*model.Verse) error) error
+	}
+)
+
+// normalizeSpace collapses runs of whitespace into single spaces
+func normalizeSpace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// appendWord adds text to the tagged words of a verse, skipping words
+// without Strong's numbers
+func appendWord(words []*model.Word, text string, strongs []string) []*model.Word {
+	if text = normalizeSpace(text); text == "" || len(strongs) == 0 {
+		return words
+	}
+	return append(words, &model.Word{
+		Position: len(words) + 1,
+		Text:     text,
+		Strongs:  strongs,
+	})
+}
+
+func setIfEmpty(field *string, value string) {
+	if *field == "" {
+		*field = normalizeSpace(value)
+	}
+}
@@ -0,0 +1,170 @@
+package reader
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/roysitumorang/bible/models"
+	"github.com/roysitumorang/bible/modules/importer/model"
+)
+
+type (
+	// structureBuilder turns the book/chapter/verse/paragraph events of
+	// marker based formats (USFM, USX) into verses that carry both the
+	// flattened text and the models.Segment structure around it
+	structureBuilder struct {
+		fn      func(*model.Verse) error
+		book    string
+		chapter int
+		verse   *model.Verse
+		// paragraph breaks and headings not yet followed by text; they
+		// belong to the current verse if its text goes on, else to the
+		// next one
+		pending []*models.Segment
+	}
+)
+
+var (
+	spaces = regexp.MustCompile(`\s+`)
+)
+
+func newStructureBuilder(fn func(*model.Verse) error) *structureBuilder {
+	return &structureBuilder{
+		fn: fn,
+	}
+}
+
+func (b *structureBuilder) setBook(code string) error {
+	if err := b.flush(); err != nil {
+		return err
+	}
+	b.book, b.chapter, b.pending = code, 0, nil
+	return nil
+}
+
+func (b *structureBuilder) setChapter(number int) error {
+	if err := b.flush(); err != nil {
+		return err
+	}
+	b.chapter = number
+	return nil
+}
+
+func (b *structureBuilder) startVerse(number int) error {
+	if err := b.flush(); err != nil {
+		return err
+	}
+	if b.book == "" || b.chapter == 0 {
+		return nil
+	}
+	b.verse = &model.Verse{
+		Book:    b.book,
+		Chapter: b.chapter,
+		Verse:   number,
+		Content: b.pending,
+	}
+	b.pending = nil
+	return nil
+}
+
+// endVerse closes the current verse without opening another, for
+// formats that mark verse ends (USX 3 eid milestones)
+func (b *structureBuilder) endVerse() error {
+	return b.flush()
+}
+
+func (b *structureBuilder) paragraph(segmentType string, level int) {
+	b.pending = append(b.pending, &models.Segment{
+		Type:  segmentType,
+		Level: level,
+	})
+}
+
+func (b *structureBuilder) heading(text, style string) {
+	if text = normalizeSpace(text); text == "" {
+		return
+	}
+	b.pending = append(b.pending, &models.Segment{
+		Type:  models.SegmentHeading,
+		Style: style,
+		Text:  text,
+	})
+}
+
+func (b *structureBuilder) text(text, style string) {
+	if b.verse == nil || text == "" {
+		return
+	}
+	if strings.TrimSpace(text) != "" {
+		b.verse.Content = append(b.verse.Content, b.pending...)
+		b.pending = nil
+	}
+	if n := len(b.verse.Content); n > 0 {
+		if last := b.verse.Content[n-1]; last.Type == models.SegmentText && last.Style == style {
+			last.Text += text
+			return
+		}
+	}
+	b.verse.Content = append(b.verse.Content, &models.Segment{
+		Type:  models.SegmentText,
+		Style: style,
+		Text:  text,
+	})
+}
+
+func (b *structureBuilder) word(text string, strongs []string) {
+	if b.verse != nil {
+		b.verse.Words = appendWord(b.verse.Words, text, strongs)
+	}
+}
+
+func (b *structureBuilder) note(segmentType, text string) {
+	if text = normalizeSpace(text); b.verse == nil || text == "" {
+		return
+	}
+	b.verse.Content = append(b.verse.Content, &models.Segment{
+		Type: segmentType,
+		Text: text,
+	})
+}
+
+func (b *structureBuilder) flush() error {
+	verse := b.verse
+	if verse == nil {
+		return nil
+	}
+	b.verse = nil
+	var (
+		text     strings.Builder
+		segments = verse.Content[:0]
+	)
+	for _, segment := range verse.Content {
+		switch segment.Type {
+		case models.SegmentText:
+			if segment.Text = spaces.ReplaceAllString(segment.Text, " "); strings.TrimSpace(segment.Text) == "" {
+				continue
+			}
+			_, _ = text.WriteString(segment.Text)
+		case models.SegmentParagraph, models.SegmentPoetry, models.SegmentBreak:
+			_ = text.WriteByte(' ')
+		}
+		segments = append(segments, segment)
+	}
+	// trim the outer spaces of the verse, keeping the inner ones that
+	// separate styled runs
+	for i := range segments {
+		if segments[i].Type == models.SegmentText {
+			segments[i].Text = strings.TrimLeft(segments[i].Text, " ")
+			break
+		}
+	}
+	for i := len(segments) - 1; i >= 0; i-- {
+		if segments[i].Type == models.SegmentText {
+			segments[i].Text = strings.TrimRight(segments[i].Text, " ")
+			break
+		}
+	}
+	verse.Content = segments
+	verse.Text = normalizeSpace(text.String())
+	return b.fn(verse)
+}
@@ -0,0 +1,285 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/roysitumorang/bible/models"
+	"github.com/roysitumorang/bible/modules/importer/model"
+	"github.com/roysitumorang/bible/strongs"
+)
+
+type (
+	// USFMReader reads one USFM file per book, as in a Paratext project
+	// folder
+	USFMReader struct {
+		fsys  fs.FS
+		paths []string
+	}
+
+	usfmToken struct {
+		marker  string
+		closing bool
+		text    string
+	}
+
+	usfmState struct {
+		builder *structureBuilder
+		// "f" or "x" while inside a footnote or cross reference
+		note     string
+		noteText strings.Builder
+		noteSkip bool
+		styles   []string
+		word     bool
+	}
+)
+
+var (
+	// markers whose text up to the next marker is not verse text
+	usfmSkipped = map[string]bool{
+		"h": true, "toc": true, "toca": true, "mt": true, "mte": true, "ide": true, "rem": true,
+		"usfm": true, "sts": true, "cl": true, "cp": true, "ca": true, "va": true, "vp": true,
+		"imt": true, "is": true, "ip": true, "ipi": true, "im": true, "imi": true, "ipq": true,
+		"imq": true, "ipr": true, "iq": true, "ib": true, "ili": true, "iot": true, "io": true,
+		"ior": true, "iqt": true, "iex": true, "imte": true, "ie": true, "cd": true, "fig": true,
+		"rq": true,
+	}
+	usfmParagraphs = map[string]bool{
+		"p": true, "m": true, "po": true, "pr": true, "cls": true, "pmo": true, "pm": true,
+		"pmc": true, "pmr": true, "pi": true, "mi": true, "nb": true, "pc": true, "ph": true,
+		"lh": true, "li": true, "lf": true, "lim": true, "tr": true,
+	}
+	usfmPoetry = map[string]bool{
+		"q": true, "qr": true, "qc": true, "qm": true, "qd": true,
+	}
+	usfmHeadings = map[string]bool{
+		"ms": true, "mr": true, "s": true, "sr": true, "r": true, "d": true, "sp": true, "sd": true, "qa": true,
+	}
+	// note content markers whose text is a label rather than note text
+	usfmNoteLabels = map[string]bool{
+		"fr": true, "xo": true, "fv": true,
+	}
+	usfmStrongAttribute = regexp.MustCompile(`\bstrong="([^"]*)"`)
+)
+
+func NewUSFMReader(fsys fs.FS, paths []string) *USFMReader {
+	return &USFMReader{
+		fsys:  fsys,
+		paths: paths,
+	}
+}
+
+func (q *USFMReader) Read(ctx context.Context, translation *model.Translation, fn func(*model.Verse) error) error {
+	for _, path := range q.paths {
+		content, err := fs.ReadFile(q.fsys, path)
+		if err != nil {
+			return err
+		}
+		state := &usfmState{
+			builder: newStructureBuilder(fn),
+		}
+		for _, token := range tokenizeUSFM(string(content)) {
+			if err := state.handle(token); err != nil {
+				return fmt.Errorf("usfm: %s: %w", path, err)
+			}
+		}
+		if err := state.builder.flush(); err != nil {
+			return fmt.Errorf("usfm: %s: %w", path, err)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tokenizeUSFM splits content into markers, each with the text up to the
+// next marker; the single space terminating a marker is dropped
+func tokenizeUSFM(content string) []usfmToken {
+	tokens := []usfmToken{{}}
+	for {
+		i := strings.IndexByte(content, '\\')
+		if i < 0 {
+			tokens[len(tokens)-1].text += content
+			return tokens
+		}
+		tokens[len(tokens)-1].text += content[:i]
+		content = content[i+1:]
+		j := strings.IndexFunc(content, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '-'
+		})
+		if j < 0 {
+			j = len(content)
+		}
+		token := usfmToken{marker: strings.TrimPrefix(content[:j], "+")}
+		content = content[j:]
+		if strings.HasPrefix(content, "*") {
+			token.closing = true
+			content = content[1:]
+		} else if len(content) > 0 && (content[0] == ' ' || content[0] == '\n' || content[0] == '\r') {
+			content = content[1:]
+		}
+		tokens = append(tokens, token)
+	}
+}
+
+// splitMarker splits a numbered marker ("q2", "s1", "toc3") into its
+// base and level, level 1 when unnumbered
+func splitMarker(marker string) (string, int) {
+	i := strings.IndexFunc(marker, unicode.IsDigit)
+	if i < 0 {
+		return marker, 1
+	}
+	level, err := strconv.Atoi(marker[i:])
+	if err != nil {
+		return marker, 1
+	}
+	return marker[:i], level
+}
+
+func (s *usfmState) handle(token usfmToken) error {
+	base, level := splitMarker(token.marker)
+	text := token.text
+	switch {
+	case token.marker == "":
+	case s.note != "":
+		switch {
+		case (base == "f" || base == "fe" || base == "x") && token.closing:
+			segmentType := models.SegmentFootnote
+			if s.note == "x" {
+				segmentType = models.SegmentCrossRef
+			}
+			s.builder.note(segmentType, s.noteText.String())
+			s.note, s.noteSkip = "", false
+			s.noteText.Reset()
+		case token.closing:
+			s.noteSkip = false
+		default:
+			s.noteSkip = usfmNoteLabels[base]
+		}
+	case base == "id":
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			return fmt.Errorf("empty \\id")
+		}
+		return s.builder.setBook(fields[0])
+	case base == "c":
+		number, _, err := leadingNumber(text)
+		if err != nil {
+			return fmt.Errorf("invalid chapter %q", strings.TrimSpace(text))
+		}
+		s.styles = nil
+		return s.builder.setChapter(number)
+	case base == "v":
+		number, rest, err := leadingNumber(text)
+		if err != nil {
+			return fmt.Errorf("invalid verse %q", strings.TrimSpace(text))
+		}
+		if err := s.builder.startVerse(number); err != nil {
+			return err
+		}
+		text = rest
+	case base == "f" || base == "fe" || base == "x":
+		s.note = base
+		if base == "fe" {
+			s.note = "f"
+		}
+		// the first field is the caller ("+", "-" or a character)
+		if fields := strings.SplitN(strings.TrimLeft(text, " "), " ", 2); len(fields) == 2 {
+			text = fields[1]
+		} else {
+			text = ""
+		}
+	case usfmSkipped[base]:
+		// the payload of \va, \fig and the like is not verse text, what
+		// follows their closing marker is
+		if !token.closing {
+			return nil
+		}
+	case usfmParagraphs[base]:
+		s.styles = nil
+		s.builder.paragraph(models.SegmentParagraph, 0)
+	case usfmPoetry[base]:
+		s.styles = nil
+		s.builder.paragraph(models.SegmentPoetry, level)
+	case base == "b":
+		s.builder.paragraph(models.SegmentBreak, 0)
+	case usfmHeadings[base]:
+		s.builder.heading(text, token.marker)
+		return nil
+	case base == "wj":
+		if token.closing {
+			if n := len(s.styles); n > 0 {
+				s.styles = s.styles[:n-1]
+			}
+		} else {
+			s.styles = append(s.styles, base)
+		}
+	case base == "w":
+		s.word = !token.closing
+	}
+	s.addText(text)
+	return nil
+}
+
+func (s *usfmState) addText(text string) {
+	if s.note != "" {
+		if !s.noteSkip {
+			_, _ = s.noteText.WriteString(text)
+		}
+		return
+	}
+	var strongs []string
+	if s.word {
+		// \w word|lemma="…" strong="H1234"\w*
+		if i := strings.IndexByte(text, '|'); i >= 0 {
+			strongs = usfmStrongs(text[i+1:])
+			text = text[:i]
+		}
+	}
+	var style string
+	if n := len(s.styles); n > 0 {
+		style = s.styles[n-1]
+	}
+	s.builder.text(text, style)
+	s.builder.word(text, strongs)
+}
+
+// usfmStrongs reads the strong attribute of a \w word attribute list
+func usfmStrongs(attributes string) []string {
+	match := usfmStrongAttribute.FindStringSubmatch(attributes)
+	if match == nil {
+		return nil
+	}
+	return strongs.Fields(match[1], "")
+}
+
+// leadingNumber parses the number at the start of text ("16", "1-2",
+// "3a"), returning the first number and the remaining text
+func leadingNumber(text string) (int, string, error) {
+	text = strings.TrimLeft(text, " \t\r\n")
+	i := strings.IndexFunc(text, unicode.IsSpace)
+	if i < 0 {
+		i = len(text)
+	}
+	field, rest := text[:i], text[i:]
+	j := strings.IndexFunc(field, func(r rune) bool {
+		return !unicode.IsDigit(r)
+	})
+	if j < 0 {
+		j = len(field)
+	}
+	number, err := strconv.Atoi(field[:j])
+	if err != nil {
+		return 0, "", err
+	}
+	if len(rest) > 0 {
+		rest = rest[1:]
+	}
+	return number, rest, nil
+}
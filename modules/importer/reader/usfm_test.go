@@ -0,0 +1,49 @@
+package reader
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/roysitumorang/bible/modules/importer/model"
+)
+
+func TestUSFMReaderSkippedMarkers(t *testing.T) {
+	for _, tc := range []struct {
+		name, content, want string
+	}{
+		{
+			name:    "alternate verse number",
+			content: `\v 1 \va 2\va* In the beginning \wj God\wj* created.`,
+			want:    "In the beginning God created.",
+		},
+		{
+			name:    "figure",
+			content: `\v 3 Let there be \fig cap|src="a.jpg"\fig* light.`,
+			want:    "Let there be light.",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fsys := fstest.MapFS{
+				"GEN.usfm": {Data: []byte("\\id GEN\n\\c 1\n\\p\n" + tc.content + "\n")},
+			}
+			var verses []*model.Verse
+			if err := NewUSFMReader(fsys, []string{"GEN.usfm"}).Read(
+				context.Background(),
+				&model.Translation{},
+				func(verse *model.Verse) error {
+					verses = append(verses, verse)
+					return nil
+				},
+			); err != nil {
+				t.Fatal(err)
+			}
+			if len(verses) != 1 {
+				t.Fatalf("got %d verses, want 1", len(verses))
+			}
+			if verses[0].Text != tc.want {
+				t.Errorf("got %q, want %q", verses[0].Text, tc.want)
+			}
+		})
+	}
+}
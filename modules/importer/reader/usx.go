@@ -0,0 +1,239 @@
+package reader
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/roysitumorang/bible/models"
+	"github.com/roysitumorang/bible/modules/importer/model"
+	"github.com/roysitumorang/bible/strongs"
+)
+
+type (
+	// USXReader reads one USX file per book, as found in a folder or a
+	// zipped bundle
+	USXReader struct {
+		fsys  fs.FS
+		paths []string
+	}
+
+	usxFrame struct {
+		name string
+		kind int
+	}
+
+	usxState struct {
+		builder      *structureBuilder
+		frames       []usxFrame
+		heading      strings.Builder
+		headingStyle string
+		note         string
+		text         strings.Builder
+		// text and Strong's numbers of the open <char style="w"> tag
+		word    strings.Builder
+		strongs []string
+	}
+)
+
+const (
+	usxPlain = iota
+	usxSkip
+	usxHeading
+	usxNote
+	usxNoteLabel
+	usxStyle
+	usxWord
+)
+
+func NewUSXReader(fsys fs.FS, paths []string) *USXReader {
+	return &USXReader{
+		fsys:  fsys,
+		paths: paths,
+	}
+}
+
+func (q *USXReader) Read(ctx context.Context, translation *model.Translation, fn func(*model.Verse) error) error {
+	for _, path := range q.paths {
+		if err := q.readFile(path, fn); err != nil {
+			return fmt.Errorf("usx: %s: %w", path, err)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *USXReader) readFile(path string, fn func(*model.Verse) error) error {
+	file, err := q.fsys.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	decoder := xml.NewDecoder(file)
+	state := &usxState{
+		builder: newStructureBuilder(fn),
+	}
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if err := state.start(t); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			if err := state.end(); err != nil {
+				return err
+			}
+		case xml.CharData:
+			state.charData(string(t))
+		}
+	}
+	return state.builder.flush()
+}
+
+// mode is the kind of the innermost frame that changes how text is read
+func (s *usxState) mode() int {
+	for i := len(s.frames) - 1; i >= 0; i-- {
+		if kind := s.frames[i].kind; kind != usxPlain && kind != usxStyle && kind != usxWord {
+			return kind
+		}
+	}
+	return usxPlain
+}
+
+func (s *usxState) style() string {
+	for i := len(s.frames) - 1; i >= 0; i-- {
+		if s.frames[i].kind == usxStyle {
+			return "wj"
+		}
+	}
+	return ""
+}
+
+func (s *usxState) start(t xml.StartElement) error {
+	frame := usxFrame{name: t.Name.Local}
+	style := attr(t, "style")
+	base, level := splitMarker(style)
+	mode := s.mode()
+	switch {
+	case mode == usxSkip || mode == usxNoteLabel:
+		frame.kind = usxSkip
+	case mode == usxNote:
+		if usfmNoteLabels[base] {
+			frame.kind = usxNoteLabel
+		}
+	case frame.name == "book":
+		frame.kind = usxSkip
+		if err := s.builder.setBook(attr(t, "code")); err != nil {
+			return err
+		}
+	case frame.name == "chapter":
+		if attr(t, "eid") != "" {
+			break
+		}
+		number, _, err := leadingNumber(attr(t, "number"))
+		if err != nil {
+			return fmt.Errorf("invalid chapter %q", attr(t, "number"))
+		}
+		if err := s.builder.setChapter(number); err != nil {
+			return err
+		}
+	case frame.name == "verse":
+		if attr(t, "eid") != "" {
+			return s.builder.endVerse()
+		}
+		number, _, err := leadingNumber(attr(t, "number"))
+		if err != nil {
+			return fmt.Errorf("invalid verse %q", attr(t, "number"))
+		}
+		if err := s.builder.startVerse(number); err != nil {
+			return err
+		}
+	case frame.name == "note":
+		frame.kind = usxNote
+		s.note = models.SegmentFootnote
+		if base == "x" {
+			s.note = models.SegmentCrossRef
+		}
+		s.text.Reset()
+	case frame.name == "para":
+		switch {
+		case usfmSkipped[base]:
+			frame.kind = usxSkip
+		case usfmHeadings[base]:
+			frame.kind = usxHeading
+			s.heading.Reset()
+			s.headingStyle = style
+		case usfmPoetry[base]:
+			s.builder.paragraph(models.SegmentPoetry, level)
+		case base == "b":
+			s.builder.paragraph(models.SegmentBreak, 0)
+		default:
+			s.builder.paragraph(models.SegmentParagraph, 0)
+		}
+	case frame.name == "char":
+		switch {
+		case base == "wj":
+			frame.kind = usxStyle
+		case base == "w" && attr(t, "strong") != "":
+			frame.kind = usxWord
+			s.word.Reset()
+			s.strongs = strongs.Fields(attr(t, "strong"), "")
+		case usfmSkipped[base]:
+			frame.kind = usxSkip
+		}
+	case frame.name == "figure" || frame.name == "sidebar":
+		frame.kind = usxSkip
+	}
+	s.frames = append(s.frames, frame)
+	return nil
+}
+
+func (s *usxState) end() error {
+	n := len(s.frames)
+	if n == 0 {
+		return nil
+	}
+	frame := s.frames[n-1]
+	s.frames = s.frames[:n-1]
+	switch frame.kind {
+	case usxHeading:
+		s.builder.heading(s.heading.String(), s.headingStyle)
+	case usxNote:
+		s.builder.note(s.note, s.text.String())
+		s.text.Reset()
+	case usxWord:
+		s.builder.word(s.word.String(), s.strongs)
+		s.word.Reset()
+		s.strongs = nil
+	}
+	return nil
+}
+
+func (s *usxState) charData(text string) {
+	switch s.mode() {
+	case usxPlain:
+		if len(s.frames) > 0 {
+			s.builder.text(text, s.style())
+		}
+		if s.strongs != nil {
+			_, _ = s.word.WriteString(text)
+		}
+	case usxHeading:
+		_, _ = s.heading.WriteString(text)
+	case usxNote:
+		_, _ = s.text.WriteString(text)
+	}
+}
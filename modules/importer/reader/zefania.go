@@ -0,0 +1,177 @@
+package reader
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/roysitumorang/bible/canon"
+	"github.com/roysitumorang/bible/modules/importer/model"
+	"github.com/roysitumorang/bible/strongs"
+)
+
+type (
+	// ZefaniaReader reads Zefania XML (<XMLBIBLE>/<BIBLEBOOK>/<CHAPTER>/<VERS>)
+	ZefaniaReader struct {
+		r io.Reader
+	}
+
+	zefaniaState struct {
+		translation *model.Translation
+		fn          func(*model.Verse) error
+		path        []string
+		book        string
+		chapter     int
+		verse       *model.Verse
+		skipDepth   int
+		text        strings.Builder
+		// Strong's prefix of the book, and depth, text and numbers of the
+		// open <gr str="…">
+		prefix    string
+		wordDepth int
+		word      strings.Builder
+		strongs   []string
+	}
+)
+
+var (
+	zefaniaSkipped = map[string]bool{
+		"NOTE":    true,
+		"CAPTION": true,
+		"REMARK":  true,
+		"XREF":    true,
+	}
+)
+
+func NewZefaniaReader(r io.Reader) *ZefaniaReader {
+	return &ZefaniaReader{
+		r: r,
+	}
+}
+
+func (q *ZefaniaReader) Read(ctx context.Context, translation *model.Translation, fn func(*model.Verse) error) error {
+	decoder := xml.NewDecoder(q.r)
+	decoder.Entity = xml.HTMLEntity
+	state := &zefaniaState{
+		translation: translation,
+		fn:          fn,
+	}
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("zefania: %w", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			state.path = append(state.path, strings.ToUpper(t.Name.Local))
+			if err := state.start(t); err != nil {
+				return fmt.Errorf("zefania: %w", err)
+			}
+		case xml.EndElement:
+			if err := state.end(); err != nil {
+				return err
+			}
+			state.path = state.path[:len(state.path)-1]
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		case xml.CharData:
+			state.charData(string(t))
+		}
+	}
+}
+
+func (s *zefaniaState) start(t xml.StartElement) error {
+	name := s.path[len(s.path)-1]
+	switch {
+	case s.skipDepth > 0:
+	case name == "XMLBIBLE":
+		setIfEmpty(&s.translation.Name, attr(t, "biblename"))
+	case name == "BIBLEBOOK":
+		// bnumber follows the canonical order for 1-66, other books
+		// (deuterocanon) resolve by name
+		s.book, s.prefix = attr(t, "bname"), strongs.Greek
+		if number, err := strconv.Atoi(attr(t, "bnumber")); err == nil && number >= 1 && number <= len(canon.Books) {
+			s.book, s.prefix = canon.Books[number-1].Code, strongs.Prefix(number)
+		}
+		if s.book == "" {
+			s.book = attr(t, "bsname")
+		}
+	case name == "CHAPTER":
+		number, err := strconv.Atoi(attr(t, "cnumber"))
+		if err != nil {
+			return fmt.Errorf("%s: invalid cnumber %q", s.book, attr(t, "cnumber"))
+		}
+		s.chapter = number
+	case name == "VERS":
+		number, _, err := leadingNumber(attr(t, "vnumber"))
+		if err != nil {
+			return fmt.Errorf("%s %d: invalid vnumber %q", s.book, s.chapter, attr(t, "vnumber"))
+		}
+		s.verse = &model.Verse{
+			Book:    s.book,
+			Chapter: s.chapter,
+			Verse:   number,
+		}
+		s.text.Reset()
+	case name == "GR" && s.verse != nil && s.wordDepth == 0:
+		if s.strongs = strongs.Fields(attr(t, "str"), s.prefix); len(s.strongs) > 0 {
+			s.wordDepth = len(s.path)
+			s.word.Reset()
+		}
+	case zefaniaSkipped[name]:
+		s.skipDepth = len(s.path)
+	}
+	return nil
+}
+
+func (s *zefaniaState) end() error {
+	depth := len(s.path)
+	switch {
+	case s.skipDepth == depth:
+		s.skipDepth = 0
+	case s.wordDepth == depth && s.verse != nil:
+		s.verse.Words = appendWord(s.verse.Words, s.word.String(), s.strongs)
+		s.wordDepth, s.strongs = 0, nil
+	case s.skipDepth == 0 && s.path[depth-1] == "VERS" && s.verse != nil:
+		verse := s.verse
+		s.verse = nil
+		verse.Text = normalizeSpace(s.text.String())
+		return s.fn(verse)
+	}
+	return nil
+}
+
+func (s *zefaniaState) charData(text string) {
+	if s.skipDepth > 0 {
+		return
+	}
+	if s.verse != nil {
+		_, _ = s.text.WriteString(text)
+		if s.wordDepth > 0 {
+			_, _ = s.word.WriteString(text)
+		}
+		return
+	}
+	if n := len(s.path); n > 1 && s.path[n-2] == "INFORMATION" {
+		switch s.path[n-1] {
+		case "TITLE":
+			setIfEmpty(&s.translation.Name, text)
+		case "LANGUAGE":
+			setIfEmpty(&s.translation.Language, text)
+		case "IDENTIFIER":
+			setIfEmpty(&s.translation.Code, strings.ToLower(text))
+		case "DESCRIPTION":
+			setIfEmptyPtr(&s.translation.Description, text)
+		case "RIGHTS":
+			setIfEmptyPtr(&s.translation.License, text)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/roysitumorang/bible/canon"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/importer/model"
+	"github.com/roysitumorang/bible/modules/importer/query"
+	"github.com/roysitumorang/bible/modules/importer/reader"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+	"github.com/roysitumorang/bible/versification"
+	"go.uber.org/zap"
+)
+
+type (
+	importerUseCase struct {
+		importerQuery  query.ImporterQuery
+		registry       *canon.Registry
+		versifications *versification.Registry
+	}
+
+	// importState carries one import from the first verse to the commit
+	importState struct {
+		tx          pgx.Tx
+		request     *model.Request
+		translation *model.Translation
+		replaced    bool
+		books       map[string]int
+		chapters    map[[2]int]int64
+		reports     map[int]*model.BookReport
+		seen        map[[2]int]bool
+		batch       []*model.Verse
+		count       int
+		tags        int
+	}
+)
+
+func NewImporterUseCase(importerQuery query.ImporterQuery, registry *canon.Registry, versifications *versification.Registry) ImporterUseCase {
+	return &importerUseCase{
+		importerQuery:  importerQuery,
+		registry:       registry,
+		versifications: versifications,
+	}
+}
+
+// Import streams the verses of source into a translation inside a single
+// transaction, so a failed import leaves the stored text untouched
+func (q *importerUseCase) Import(ctx context.Context, source reader.Reader, request *model.Request) (*model.Report, error) {
+	ctxt := "ImporterUseCase-Import"
+	now := time.Now()
+	tx, err := q.importerQuery.Begin(ctx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrBegin")
+		return nil, err
+	}
+	defer func() {
+		if errRollback := tx.Rollback(ctx); errRollback != nil && !errors.Is(errRollback, pgx.ErrTxClosed) {
+			helper.Capture(ctx, zap.ErrorLevel, errRollback, ctxt, "ErrRollback")
+		}
+	}()
+	state := &importState{
+		tx:          tx,
+		request:     request,
+		translation: &request.Translation,
+		books:       map[string]int{},
+		reports:     map[int]*model.BookReport{},
+		seen:        map[[2]int]bool{},
+	}
+	if err := source.Read(ctx, state.translation, func(verse *model.Verse) error {
+		return q.addVerse(ctx, state, verse)
+	}); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrRead")
+		return nil, err
+	}
+	if err := q.flush(ctx, state); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFlush")
+		return nil, err
+	}
+	if state.count == 0 {
+		return nil, model.ErrNoVerses
+	}
+	if err := tx.Commit(ctx); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCommit")
+		return nil, err
+	}
+	response := model.Report{
+		Translation: state.translation.Code,
+		Replaced:    state.replaced,
+		Books:       make([]*model.BookReport, 0, len(state.reports)),
+		Verses:      state.count,
+		Tags:        state.tags,
+		Duration:    time.Since(now),
+	}
+	bookIDs := make([]int, 0, len(state.reports))
+	for bookID := range state.reports {
+		bookIDs = append(bookIDs, bookID)
+	}
+	sort.Ints(bookIDs)
+	for _, bookID := range bookIDs {
+		response.Books = append(response.Books, state.reports[bookID])
+	}
+	return &response, nil
+}
+
+func (q *importerUseCase) addVerse(ctx context.Context, state *importState, verse *model.Verse) error {
+	ctxt := "ImporterUseCase-addVerse"
+	if state.translation.ID == 0 {
+		if err := q.prepareTranslation(ctx, state); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrPrepareTranslation")
+			return err
+		}
+	}
+	bookID, ok := state.books[verse.Book]
+	if !ok {
+		book, ok := q.registry.ResolveBook(verse.Book)
+		if !ok {
+			return fmt.Errorf("unknown book %q (chapter %d, verse %d)", verse.Book, verse.Chapter, verse.Verse)
+		}
+		bookID = book.Order
+		state.books[verse.Book] = bookID
+	}
+	if verse.Chapter < 1 || verse.Chapter > scriptureModel.MaxChapter || verse.Verse < 1 || verse.Verse > scriptureModel.MaxVerse {
+		return fmt.Errorf("invalid verse number %s %d:%d", verse.Book, verse.Chapter, verse.Verse)
+	}
+	key := [2]int{bookID, verse.Chapter}
+	chapterID, ok := state.chapters[key]
+	if !ok {
+		var err error
+		if chapterID, err = q.importerQuery.CreateChapter(ctx, state.tx, bookID, verse.Chapter); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCreateChapter")
+			return err
+		}
+		state.chapters[key] = chapterID
+	}
+	verse.BookID, verse.ChapterID = bookID, chapterID
+	report, ok := state.reports[bookID]
+	if !ok {
+		book := canon.Books[bookID-1]
+		report = &model.BookReport{Book: book.Code}
+		state.reports[bookID] = report
+	}
+	if !state.seen[key] {
+		state.seen[key] = true
+		report.Chapters++
+	}
+	report.Verses++
+	state.batch = append(state.batch, verse)
+	if len(state.batch) >= model.BatchSize {
+		return q.flush(ctx, state)
+	}
+	return nil
+}
+
+// prepareTranslation runs on the first verse, once the source had the
+// chance to fill in its metadata
+func (q *importerUseCase) prepareTranslation(ctx context.Context, state *importState) error {
+	ctxt := "ImporterUseCase-prepareTranslation"
+	translation := state.translation
+	translation.Code = strings.ToLower(strings.TrimSpace(translation.Code))
+	if translation.Code == "" || translation.Name == "" || translation.Language == "" {
+		return model.ErrTranslationInvalid
+	}
+	if translation.TextSearchConfig == "" {
+		translation.TextSearchConfig = model.TextSearchConfigs[strings.ToLower(translation.Language)]
+	}
+	if translation.Versification = strings.ToLower(translation.Versification); translation.Versification == "" {
+		translation.Versification = versification.Standard
+	}
+	if _, ok := q.versifications.Scheme(translation.Versification); !ok {
+		return fmt.Errorf("%w: %s", versification.ErrUnknownScheme, translation.Versification)
+	}
+	existing, err := q.importerQuery.FindTranslationByCode(ctx, state.tx, translation.Code)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindTranslationByCode")
+		return err
+	}
+	if existing != nil {
+		if !state.request.Overwrite {
+			return fmt.Errorf("%s: %w", translation.Code, model.ErrTranslationExists)
+		}
+		translation.ID = existing.ID
+		if err := q.importerQuery.UpdateTranslation(ctx, state.tx, translation); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrUpdateTranslation")
+			return err
+		}
+		if _, err := q.importerQuery.DeleteVerses(ctx, state.tx, translation.ID); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrDeleteVerses")
+			return err
+		}
+		state.replaced = true
+	} else if translation.ID, err = q.importerQuery.CreateTranslation(ctx, state.tx, translation); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCreateTranslation")
+		return err
+	}
+	chapters, err := q.importerQuery.FindChapters(ctx, state.tx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindChapters")
+		return err
+	}
+	state.chapters = make(map[[2]int]int64, len(chapters))
+	for _, chapter := range chapters {
+		state.chapters[[2]int{chapter.BookID, chapter.Number}] = chapter.ID
+	}
+	return nil
+}
+
+func (q *importerUseCase) flush(ctx context.Context, state *importState) error {
+	ctxt := "ImporterUseCase-flush"
+	if len(state.batch) == 0 {
+		return nil
+	}
+	count, err := q.importerQuery.CopyVerses(ctx, state.tx, state.translation.ID, state.batch)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCopyVerses")
+		return err
+	}
+	tags, err := q.importerQuery.CopyWords(ctx, state.tx, state.translation.ID, state.batch)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCopyWords")
+		return err
+	}
+	state.count += int(count)
+	state.tags += int(tags)
+	state.batch = state.batch[:0]
+	return nil
+}
@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/modules/importer/model"
+	"github.com/roysitumorang/bible/modules/importer/reader"
+)
+
+type (
+	ImporterUseCase interface {
+		Import(ctx context.Context, source reader.Reader, request *model.Request) (*model.Report, error)
+	}
+)
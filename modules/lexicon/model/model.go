@@ -0,0 +1,78 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/roysitumorang/bible/models"
+)
+
+type (
+	Entry struct {
+		Strong          string    `json:"strong"`
+		Language        string    `json:"language"`
+		Lemma           string    `json:"lemma"`
+		Transliteration *string   `json:"transliteration"`
+		Pronunciation   *string   `json:"pronunciation"`
+		Derivation      *string   `json:"derivation"`
+		Definition      *string   `json:"definition"`
+		KJVUsage        *string   `json:"kjv_usage"`
+		CreatedAt       time.Time `json:"created_at"`
+		UpdatedAt       time.Time `json:"updated_at"`
+	}
+
+	// SourceEntry is an entry of the public domain Strong's dictionaries
+	// as published by Open Scriptures, keyed by Strong's number; the
+	// Hebrew one transliterates in xlit, the Greek one in translit
+	SourceEntry struct {
+		Lemma      string `json:"lemma"`
+		Xlit       string `json:"xlit"`
+		Translit   string `json:"translit"`
+		Pron       string `json:"pron"`
+		Derivation string `json:"derivation"`
+		StrongsDef string `json:"strongs_def"`
+		KJVDef     string `json:"kjv_def"`
+	}
+
+	Filter struct {
+		Strong       string
+		Translations []string
+		Page         int
+		PerPage      int
+	}
+
+	// Occurrence is a verse containing the Strong's number, with the words
+	// tagged with it in verse order
+	Occurrence struct {
+		Translation string   `json:"translation"`
+		Book        string   `json:"book"`
+		Chapter     int      `json:"chapter"`
+		Verse       int      `json:"verse"`
+		Text        string   `json:"text"`
+		Words       []string `json:"words"`
+	}
+
+	Response struct {
+		Pagination  *models.Pagination `json:"pagination"`
+		Occurrences []*Occurrence      `json:"occurrences"`
+	}
+
+	ImportReport struct {
+		Hebrew   int           `json:"hebrew"`
+		Greek    int           `json:"greek"`
+		Duration time.Duration `json:"duration"`
+	}
+)
+
+const (
+	LanguageHebrew = "hbo"
+	LanguageGreek  = "grc"
+
+	DefaultPerPage = 10
+)
+
+var (
+	ErrInvalidStrong = errors.New("strong: expected a Strong's number such as H7225 or G26")
+	ErrEntryNotFound = errors.New("lexicon entry not found")
+	ErrNoEntries     = errors.New("no lexicon entries found")
+)
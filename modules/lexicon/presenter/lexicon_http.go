@@ -0,0 +1,80 @@
+package presenter
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/lexicon/model"
+	"github.com/roysitumorang/bible/modules/lexicon/usecase"
+	"go.uber.org/zap"
+)
+
+type (
+	LexiconHTTPHandler struct {
+		lexiconUseCase usecase.LexiconUseCase
+	}
+)
+
+func NewLexiconHTTPHandler(lexiconUseCase usecase.LexiconUseCase) *LexiconHTTPHandler {
+	return &LexiconHTTPHandler{
+		lexiconUseCase: lexiconUseCase,
+	}
+}
+
+func (q *LexiconHTTPHandler) Mount(r fiber.Router) {
+	r.Get("/:strong", q.FindEntry).
+		Get("/:strong/verses", q.FindOccurrences)
+}
+
+// FindEntry handles GET /v1/lexicon/H7225
+func (q *LexiconHTTPHandler) FindEntry(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "LexiconHTTPHandler-FindEntry"
+	response, err := q.lexiconUseCase.FindEntry(ctx, c.Params("strong"))
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrFindEntry")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(fiber.StatusOK, "", response).WriteResponse(c)
+}
+
+// FindOccurrences handles GET /v1/lexicon/G26/verses?translation=kjv,tb&page=1&per_page=10
+func (q *LexiconHTTPHandler) FindOccurrences(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "LexiconHTTPHandler-FindOccurrences"
+	filter := model.Filter{
+		Strong: c.Params("strong"),
+	}
+	var err error
+	if filter.Page, filter.PerPage, err = helper.ParsePage(c, model.DefaultPerPage); err != nil {
+		return helper.NewResponse(fiber.StatusBadRequest, err.Error(), nil).WriteResponse(c)
+	}
+	if translations := c.Query("translation"); translations != "" {
+		filter.Translations = strings.Split(translations, ",")
+	}
+	occurrences, total, err := q.lexiconUseCase.FindOccurrences(ctx, &filter)
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrFindOccurrences")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(
+		fiber.StatusOK,
+		"",
+		&model.Response{
+			Pagination:  helper.NewPagination(c, filter.Page, filter.PerPage, total),
+			Occurrences: occurrences,
+		},
+	).WriteResponse(c)
+}
+
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, model.ErrInvalidStrong):
+		return fiber.StatusBadRequest
+	case errors.Is(err, model.ErrEntryNotFound):
+		return fiber.StatusNotFound
+	}
+	return fiber.StatusInternalServerError
+}
@@ -0,0 +1,211 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/lexicon/model"
+	"go.uber.org/zap"
+)
+
+type (
+	lexiconQuery struct {
+		dbRead  *pgxpool.Pool
+		dbWrite *pgxpool.Pool
+	}
+)
+
+const (
+	occurrencesFrom = `FROM verse_words w
+		JOIN translations t ON t."id" = w."translation_id"
+		WHERE w."strong" = $1`
+)
+
+var (
+	entryColumns = []string{"strong", "language", "lemma", "transliteration", "pronunciation", "derivation", "definition", "kjv_usage"}
+)
+
+func NewLexiconQuery(dbRead, dbWrite *pgxpool.Pool) LexiconQuery {
+	return &lexiconQuery{
+		dbRead:  dbRead,
+		dbWrite: dbWrite,
+	}
+}
+
+func (q *lexiconQuery) FindEntry(ctx context.Context, strong string) (*model.Entry, error) {
+	ctxt := "LexiconQuery-FindEntry"
+	var response model.Entry
+	err := q.dbRead.QueryRow(
+		ctx,
+		`SELECT "strong", "language", "lemma", "transliteration", "pronunciation", "derivation", "definition", "kjv_usage", "created_at", "updated_at"
+		FROM lexicon_entries
+		WHERE "strong" = $1`,
+		strong,
+	).Scan(
+		&response.Strong,
+		&response.Language,
+		&response.Lemma,
+		&response.Transliteration,
+		&response.Pronunciation,
+		&response.Derivation,
+		&response.Definition,
+		&response.KJVUsage,
+		&response.CreatedAt,
+		&response.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, model.ErrEntryNotFound
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return nil, err
+	}
+	return &response, nil
+}
+
+// CountOccurrences counts verses, not words: a number tagged twice in a
+// verse is one occurrence
+func (q *lexiconQuery) CountOccurrences(ctx context.Context, filter *model.Filter) (int, error) {
+	ctxt := "LexiconQuery-CountOccurrences"
+	conditions, params := q.conditions(filter)
+	var count int
+	if err := q.dbRead.QueryRow(
+		ctx,
+		fmt.Sprintf(`SELECT COUNT(DISTINCT (w."translation_id", w."ordinal")) %s%s`, occurrencesFrom, conditions),
+		params...,
+	).Scan(&count); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return 0, err
+	}
+	return count, nil
+}
+
+func (q *lexiconQuery) FindOccurrences(ctx context.Context, filter *model.Filter) ([]*model.Occurrence, error) {
+	ctxt := "LexiconQuery-FindOccurrences"
+	conditions, params := q.conditions(filter)
+	params = append(params, filter.PerPage, helper.Offset(filter.Page, filter.PerPage))
+	rows, err := q.dbRead.Query(
+		ctx,
+		fmt.Sprintf(
+			`SELECT t."code", b."code", v."chapter", v."verse", v."text", ARRAY_AGG(w."word" ORDER BY w."position")
+			FROM (
+				SELECT w."translation_id", w."ordinal", w."position", w."word"
+				%s%s
+			) w
+			JOIN translations t ON t."id" = w."translation_id"
+			JOIN verses v ON v."translation_id" = w."translation_id" AND v."ordinal" = w."ordinal"
+			JOIN books b ON b."id" = v."book_id"
+			GROUP BY t."code", b."code", v."id"
+			ORDER BY v."ordinal", t."code"
+			LIMIT $%d OFFSET $%d`,
+			occurrencesFrom,
+			conditions,
+			len(params)-1,
+			len(params),
+		),
+		params...,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return nil, err
+	}
+	defer rows.Close()
+	var response []*model.Occurrence
+	for rows.Next() {
+		var occurrence model.Occurrence
+		if err := rows.Scan(
+			&occurrence.Translation,
+			&occurrence.Book,
+			&occurrence.Chapter,
+			&occurrence.Verse,
+			&occurrence.Text,
+			&occurrence.Words,
+		); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return nil, err
+		}
+		response = append(response, &occurrence)
+	}
+	return response, nil
+}
+
+func (q *lexiconQuery) conditions(filter *model.Filter) (string, []interface{}) {
+	var builder strings.Builder
+	params := []interface{}{filter.Strong}
+	if len(filter.Translations) > 0 {
+		params = append(params, filter.Translations)
+		_, _ = fmt.Fprintf(&builder, ` AND t."code" = ANY($%d)`, len(params))
+	}
+	return builder.String(), params
+}
+
+func (q *lexiconQuery) Begin(ctx context.Context) (pgx.Tx, error) {
+	ctxt := "LexiconQuery-Begin"
+	tx, err := q.dbWrite.Begin(ctx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrBegin")
+	}
+	return tx, err
+}
+
+// SaveEntries upserts entries by Strong's number, copying them into a
+// temporary table first so a whole dictionary is one round trip
+func (q *lexiconQuery) SaveEntries(ctx context.Context, tx pgx.Tx, entries []*model.Entry) (int64, error) {
+	ctxt := "LexiconQuery-SaveEntries"
+	if _, err := tx.Exec(
+		ctx,
+		`CREATE TEMPORARY TABLE lexicon_import (LIKE lexicon_entries INCLUDING DEFAULTS) ON COMMIT DROP`,
+	); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+		return 0, err
+	}
+	if _, err := tx.CopyFrom(
+		ctx,
+		pgx.Identifier{"lexicon_import"},
+		entryColumns,
+		pgx.CopyFromSlice(len(entries), func(i int) ([]interface{}, error) {
+			entry := entries[i]
+			return []interface{}{
+				entry.Strong,
+				entry.Language,
+				entry.Lemma,
+				entry.Transliteration,
+				entry.Pronunciation,
+				entry.Derivation,
+				entry.Definition,
+				entry.KJVUsage,
+			}, nil
+		}),
+	); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCopyFrom")
+		return 0, err
+	}
+	result, err := tx.Exec(
+		ctx,
+		`INSERT INTO lexicon_entries ("strong", "language", "lemma", "transliteration", "pronunciation", "derivation", "definition", "kjv_usage")
+		SELECT "strong", "language", "lemma", "transliteration", "pronunciation", "derivation", "definition", "kjv_usage"
+		FROM lexicon_import
+		ON CONFLICT ("strong") DO UPDATE SET
+			"language" = EXCLUDED."language"
+			, "lemma" = EXCLUDED."lemma"
+			, "transliteration" = EXCLUDED."transliteration"
+			, "pronunciation" = EXCLUDED."pronunciation"
+			, "derivation" = EXCLUDED."derivation"
+			, "definition" = EXCLUDED."definition"
+			, "kjv_usage" = EXCLUDED."kjv_usage"
+			, "updated_at" = CURRENT_TIMESTAMP`,
+	)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
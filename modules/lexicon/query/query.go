@@ -0,0 +1,18 @@
+package query
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/roysitumorang/bible/modules/lexicon/model"
+)
+
+type (
+	LexiconQuery interface {
+		FindEntry(ctx context.Context, strong string) (*model.Entry, error)
+		CountOccurrences(ctx context.Context, filter *model.Filter) (int, error)
+		FindOccurrences(ctx context.Context, filter *model.Filter) ([]*model.Occurrence, error)
+		Begin(ctx context.Context) (pgx.Tx, error)
+		SaveEntries(ctx context.Context, tx pgx.Tx, entries []*model.Entry) (int64, error)
+	}
+)
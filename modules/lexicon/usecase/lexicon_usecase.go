@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/jackc/pgx/v5"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/lexicon/model"
+	"github.com/roysitumorang/bible/modules/lexicon/query"
+	"github.com/roysitumorang/bible/strongs"
+	"go.uber.org/zap"
+)
+
+type (
+	lexiconUseCase struct {
+		lexiconQuery query.LexiconQuery
+	}
+)
+
+func NewLexiconUseCase(lexiconQuery query.LexiconQuery) LexiconUseCase {
+	return &lexiconUseCase{
+		lexiconQuery: lexiconQuery,
+	}
+}
+
+func (q *lexiconUseCase) FindEntry(ctx context.Context, strong string) (*model.Entry, error) {
+	ctxt := "LexiconUseCase-FindEntry"
+	number, ok := strongs.Normalize(strong)
+	if !ok {
+		return nil, model.ErrInvalidStrong
+	}
+	response, err := q.lexiconQuery.FindEntry(ctx, number)
+	if err != nil && !errors.Is(err, model.ErrEntryNotFound) {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindEntry")
+	}
+	return response, err
+}
+
+// FindOccurrences returns one page of the verses tagged with
+// filter.Strong, in canonical order, and the total number of such verses
+func (q *lexiconUseCase) FindOccurrences(ctx context.Context, filter *model.Filter) ([]*model.Occurrence, int, error) {
+	ctxt := "LexiconUseCase-FindOccurrences"
+	number, ok := strongs.Normalize(filter.Strong)
+	if !ok {
+		return nil, 0, model.ErrInvalidStrong
+	}
+	filter.Strong = number
+	if filter.PerPage == 0 {
+		filter.PerPage = model.DefaultPerPage
+	}
+	filter.Page = max(filter.Page, 1)
+	for i, translation := range filter.Translations {
+		filter.Translations[i] = strings.ToLower(translation)
+	}
+	response, err := q.lexiconQuery.FindOccurrences(ctx, filter)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindOccurrences")
+		return nil, 0, err
+	}
+	total, err := helper.Total(filter.Page, filter.PerPage, len(response), func() (int, error) {
+		return q.lexiconQuery.CountOccurrences(ctx, filter)
+	})
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCountOccurrences")
+		return nil, 0, err
+	}
+	return response, total, nil
+}
+
+// Import upserts the entries of a Strong's dictionary, either plain JSON
+// or the JavaScript file it is distributed as (var x = {…}; …)
+func (q *lexiconUseCase) Import(ctx context.Context, r io.Reader) (*model.ImportReport, error) {
+	ctxt := "LexiconUseCase-Import"
+	now := time.Now()
+	content, err := io.ReadAll(r)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrReadAll")
+		return nil, err
+	}
+	if start, end := bytes.IndexByte(content, '{'), bytes.LastIndexByte(content, '}'); start >= 0 && end > start {
+		content = content[start : end+1]
+	}
+	var source map[string]*model.SourceEntry
+	if err := json.Unmarshal(content, &source); err != nil {
+		return nil, fmt.Errorf("lexicon: %w", err)
+	}
+	var response model.ImportReport
+	entries := make([]*model.Entry, 0, len(source))
+	// keys differing in zero padding or suffix only ("H1", "H0001") are
+	// one entry: the key already in normal form wins, then the first in
+	// key order, so every import of a file keeps the same one
+	numbers := make(map[string]string, len(source))
+	keys := make([]string, 0, len(source))
+	for key := range source {
+		numbers[key], _ = strongs.Normalize(key)
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if normal := keys[i] == numbers[keys[i]]; normal != (keys[j] == numbers[keys[j]]) {
+			return normal
+		}
+		return keys[i] < keys[j]
+	})
+	seen := make(map[string]bool, len(source))
+	for _, key := range keys {
+		item := source[key]
+		number, ok := numbers[key], numbers[key] != ""
+		if !ok || seen[number] || item == nil || strings.TrimSpace(item.Lemma) == "" {
+			continue
+		}
+		seen[number] = true
+		entry := model.Entry{
+			Strong:          number,
+			Language:        model.LanguageHebrew,
+			Lemma:           strings.TrimSpace(item.Lemma),
+			Transliteration: optional(item.Xlit),
+			Pronunciation:   optional(item.Pron),
+			Derivation:      optional(item.Derivation),
+			Definition:      optional(item.StrongsDef),
+			KJVUsage:        optional(item.KJVDef),
+		}
+		if entry.Transliteration == nil {
+			entry.Transliteration = optional(item.Translit)
+		}
+		if strings.HasPrefix(number, strongs.Greek) {
+			entry.Language = model.LanguageGreek
+			response.Greek++
+		} else {
+			response.Hebrew++
+		}
+		entries = append(entries, &entry)
+	}
+	if len(entries) == 0 {
+		return nil, model.ErrNoEntries
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Strong < entries[j].Strong
+	})
+	tx, err := q.lexiconQuery.Begin(ctx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrBegin")
+		return nil, err
+	}
+	defer func() {
+		if errRollback := tx.Rollback(ctx); errRollback != nil && !errors.Is(errRollback, pgx.ErrTxClosed) {
+			helper.Capture(ctx, zap.ErrorLevel, errRollback, ctxt, "ErrRollback")
+		}
+	}()
+	if _, err := q.lexiconQuery.SaveEntries(ctx, tx, entries); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrSaveEntries")
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCommit")
+		return nil, err
+	}
+	response.Duration = time.Since(now)
+	return &response, nil
+}
+
+func optional(value string) *string {
+	if value = strings.TrimSpace(value); value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+	"io"
+
+	"github.com/roysitumorang/bible/modules/lexicon/model"
+)
+
+type (
+	LexiconUseCase interface {
+		FindEntry(ctx context.Context, strong string) (*model.Entry, error)
+		FindOccurrences(ctx context.Context, filter *model.Filter) ([]*model.Occurrence, int, error)
+		Import(ctx context.Context, r io.Reader) (*model.ImportReport, error)
+	}
+)
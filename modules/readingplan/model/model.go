@@ -0,0 +1,84 @@
+package model
+
+import (
+	"embed"
+	"errors"
+	"time"
+
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+)
+
+type (
+	Plan struct {
+		ID          int64     `json:"-"`
+		Code        string    `json:"code"`
+		Name        string    `json:"name"`
+		Description *string   `json:"description"`
+		DaysCount   int       `json:"days_count"`
+		CreatedAt   time.Time `json:"created_at"`
+		UpdatedAt   time.Time `json:"updated_at"`
+	}
+
+	// Day is the reading of one day of a plan; Passage is only filled in
+	// when a translation is requested
+	Day struct {
+		Plan     *Plan      `json:"plan"`
+		Day      int        `json:"day"`
+		Readings []*Reading `json:"readings"`
+	}
+
+	Reading struct {
+		Reference string                  `json:"reference"`
+		Passage   *scriptureModel.Passage `json:"passage,omitempty"`
+	}
+
+	// Enrollment is a user following a plan from StartDate; CurrentDay is
+	// the plan day of today in helper.LoadTimeZone, below 1 before the
+	// start and above the days count once finished
+	Enrollment struct {
+		ID         int64     `json:"-"`
+		PlanID     int64     `json:"-"`
+		Plan       string    `json:"plan"`
+		UserID     string    `json:"-"`
+		StartDate  time.Time `json:"-"`
+		Start      string    `json:"start_date"`
+		CurrentDay int       `json:"current_day"`
+		CreatedAt  time.Time `json:"created_at"`
+		UpdatedAt  time.Time `json:"updated_at"`
+	}
+
+	// File is a plan as found in the data files, one list of references
+	// per day
+	File struct {
+		Code        string     `json:"code"`
+		Name        string     `json:"name"`
+		Description string     `json:"description"`
+		Days        [][]string `json:"days"`
+	}
+
+	Passage struct {
+		DayID     int64
+		Position  int
+		Reference string
+	}
+
+	LoadReport struct {
+		Plan     string `json:"plan"`
+		Days     int    `json:"days"`
+		Readings int    `json:"readings"`
+	}
+)
+
+const (
+	DateLayout = "2006-01-02"
+)
+
+var (
+	//go:embed data/*.json
+	Data embed.FS
+
+	ErrPlanNotFound     = errors.New("reading plan not found")
+	ErrDayNotFound      = errors.New("reading plan day not found")
+	ErrInvalidStartDate = errors.New("start_date: expected YYYY-MM-DD")
+	ErrInvalidPlan      = errors.New("plan code, name and days are required")
+)
@@ -0,0 +1,93 @@
+package presenter
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/middleware"
+	"github.com/roysitumorang/bible/modules/readingplan/model"
+	"github.com/roysitumorang/bible/modules/readingplan/usecase"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+	"go.uber.org/zap"
+)
+
+type (
+	ReadingPlanHTTPHandler struct {
+		readingPlanUseCase usecase.ReadingPlanUseCase
+	}
+
+	enrollRequest struct {
+		StartDate string `json:"start_date"`
+	}
+)
+
+func NewReadingPlanHTTPHandler(readingPlanUseCase usecase.ReadingPlanUseCase) *ReadingPlanHTTPHandler {
+	return &ReadingPlanHTTPHandler{
+		readingPlanUseCase: readingPlanUseCase,
+	}
+}
+
+func (q *ReadingPlanHTTPHandler) Mount(r fiber.Router) {
+	r.Get("", q.FindPlans).
+		Get("/:plan/days/:day", q.FindDay).
+		Post("/:plan/enrollments", middleware.BearerAuth(), q.Enroll)
+}
+
+func (q *ReadingPlanHTTPHandler) FindPlans(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "ReadingPlanHTTPHandler-FindPlans"
+	response, err := q.readingPlanUseCase.FindPlans(ctx)
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrFindPlans")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(fiber.StatusOK, "", response).WriteResponse(c)
+}
+
+// FindDay handles GET /v1/reading-plans/:plan/days/:day?translation=kjv
+func (q *ReadingPlanHTTPHandler) FindDay(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "ReadingPlanHTTPHandler-FindDay"
+	day, err := c.ParamsInt("day")
+	if err != nil || day < 1 {
+		return helper.NewResponse(fiber.StatusBadRequest, "day: positive integer required", nil).WriteResponse(c)
+	}
+	response, err := q.readingPlanUseCase.FindDay(ctx, c.Params("plan"), day, c.Query("translation"))
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrFindDay")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(fiber.StatusOK, "", response).WriteResponse(c)
+}
+
+// Enroll handles POST /v1/reading-plans/:plan/enrollments with an optional
+// {"start_date": "2025-01-01"} body, the token subject being the user
+func (q *ReadingPlanHTTPHandler) Enroll(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "ReadingPlanHTTPHandler-Enroll"
+	var request enrollRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&request); err != nil {
+			return helper.NewResponse(fiber.StatusBadRequest, err.Error(), nil).WriteResponse(c)
+		}
+	}
+	response, err := q.readingPlanUseCase.Enroll(ctx, middleware.Subject(c), c.Params("plan"), request.StartDate)
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrEnroll")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(fiber.StatusCreated, "", response).WriteResponse(c)
+}
+
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, model.ErrPlanNotFound),
+		errors.Is(err, model.ErrDayNotFound),
+		errors.Is(err, scriptureModel.ErrTranslationNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, model.ErrInvalidStartDate):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
@@ -0,0 +1,22 @@
+package query
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/roysitumorang/bible/modules/readingplan/model"
+)
+
+type (
+	ReadingPlanQuery interface {
+		FindPlans(ctx context.Context) ([]*model.Plan, error)
+		FindPlanByCode(ctx context.Context, code string) (*model.Plan, error)
+		FindReferences(ctx context.Context, planID int64, day int) ([]string, error)
+		SaveEnrollment(ctx context.Context, enrollment *model.Enrollment) error
+		Begin(ctx context.Context) (pgx.Tx, error)
+		SavePlan(ctx context.Context, tx pgx.Tx, plan *model.Plan) (int64, error)
+		DeleteDays(ctx context.Context, tx pgx.Tx, planID int64) error
+		CreateDays(ctx context.Context, tx pgx.Tx, planID int64, count int) ([]int64, error)
+		CopyPassages(ctx context.Context, tx pgx.Tx, passages []*model.Passage) (int64, error)
+	}
+)
@@ -0,0 +1,250 @@
+package query
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/readingplan/model"
+	"go.uber.org/zap"
+)
+
+type (
+	readingPlanQuery struct {
+		dbRead  *pgxpool.Pool
+		dbWrite *pgxpool.Pool
+	}
+)
+
+var (
+	passageColumns = []string{"day_id", "position", "reference"}
+)
+
+func NewReadingPlanQuery(dbRead, dbWrite *pgxpool.Pool) ReadingPlanQuery {
+	return &readingPlanQuery{
+		dbRead:  dbRead,
+		dbWrite: dbWrite,
+	}
+}
+
+func (q *readingPlanQuery) FindPlans(ctx context.Context) ([]*model.Plan, error) {
+	ctxt := "ReadingPlanQuery-FindPlans"
+	rows, err := q.dbRead.Query(
+		ctx,
+		`SELECT "id", "code", "name", "description", "days_count", "created_at", "updated_at"
+		FROM reading_plans
+		ORDER BY "days_count", "code"`,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return nil, err
+	}
+	defer rows.Close()
+	var response []*model.Plan
+	for rows.Next() {
+		var plan model.Plan
+		if err := rows.Scan(
+			&plan.ID,
+			&plan.Code,
+			&plan.Name,
+			&plan.Description,
+			&plan.DaysCount,
+			&plan.CreatedAt,
+			&plan.UpdatedAt,
+		); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return nil, err
+		}
+		response = append(response, &plan)
+	}
+	return response, nil
+}
+
+func (q *readingPlanQuery) FindPlanByCode(ctx context.Context, code string) (*model.Plan, error) {
+	ctxt := "ReadingPlanQuery-FindPlanByCode"
+	var response model.Plan
+	err := q.dbRead.QueryRow(
+		ctx,
+		`SELECT "id", "code", "name", "description", "days_count", "created_at", "updated_at"
+		FROM reading_plans
+		WHERE "code" = $1`,
+		code,
+	).Scan(
+		&response.ID,
+		&response.Code,
+		&response.Name,
+		&response.Description,
+		&response.DaysCount,
+		&response.CreatedAt,
+		&response.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, model.ErrPlanNotFound
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (q *readingPlanQuery) FindReferences(ctx context.Context, planID int64, day int) ([]string, error) {
+	ctxt := "ReadingPlanQuery-FindReferences"
+	rows, err := q.dbRead.Query(
+		ctx,
+		`SELECT p."reference"
+		FROM reading_plan_days d
+		JOIN reading_plan_passages p ON p."day_id" = d."id"
+		WHERE d."plan_id" = $1
+			AND d."day" = $2
+		ORDER BY p."position"`,
+		planID,
+		day,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return nil, err
+	}
+	defer rows.Close()
+	var response []string
+	for rows.Next() {
+		var reference string
+		if err := rows.Scan(&reference); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return nil, err
+		}
+		response = append(response, reference)
+	}
+	return response, nil
+}
+
+// SaveEnrollment enrolls the user in the plan, moving the start date of
+// an existing enrollment
+func (q *readingPlanQuery) SaveEnrollment(ctx context.Context, enrollment *model.Enrollment) error {
+	ctxt := "ReadingPlanQuery-SaveEnrollment"
+	if err := q.dbWrite.QueryRow(
+		ctx,
+		`INSERT INTO reading_plan_enrollments ("plan_id", "user_id", "start_date")
+		VALUES ($1, $2, $3)
+		ON CONFLICT ("user_id", "plan_id") DO UPDATE SET
+			"start_date" = EXCLUDED."start_date"
+			, "updated_at" = CURRENT_TIMESTAMP
+		RETURNING "id", "created_at", "updated_at"`,
+		enrollment.PlanID,
+		enrollment.UserID,
+		enrollment.StartDate,
+	).Scan(
+		&enrollment.ID,
+		&enrollment.CreatedAt,
+		&enrollment.UpdatedAt,
+	); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return err
+	}
+	return nil
+}
+
+func (q *readingPlanQuery) Begin(ctx context.Context) (pgx.Tx, error) {
+	ctxt := "ReadingPlanQuery-Begin"
+	tx, err := q.dbWrite.Begin(ctx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrBegin")
+	}
+	return tx, err
+}
+
+// SavePlan creates the plan or updates the one with the same code,
+// keeping its enrollments
+func (q *readingPlanQuery) SavePlan(ctx context.Context, tx pgx.Tx, plan *model.Plan) (int64, error) {
+	ctxt := "ReadingPlanQuery-SavePlan"
+	var id int64
+	err := tx.QueryRow(
+		ctx,
+		`INSERT INTO reading_plans ("code", "name", "description", "days_count")
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT ("code") DO UPDATE SET
+			"name" = EXCLUDED."name"
+			, "description" = EXCLUDED."description"
+			, "days_count" = EXCLUDED."days_count"
+			, "updated_at" = CURRENT_TIMESTAMP
+		RETURNING "id"`,
+		plan.Code,
+		plan.Name,
+		plan.Description,
+		plan.DaysCount,
+	).Scan(&id)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+	}
+	return id, err
+}
+
+func (q *readingPlanQuery) DeleteDays(ctx context.Context, tx pgx.Tx, planID int64) error {
+	ctxt := "ReadingPlanQuery-DeleteDays"
+	if _, err := tx.Exec(ctx, `DELETE FROM reading_plan_days WHERE "plan_id" = $1`, planID); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrExec")
+		return err
+	}
+	return nil
+}
+
+// CreateDays creates days 1..count of the plan, returning their IDs in
+// day order
+func (q *readingPlanQuery) CreateDays(ctx context.Context, tx pgx.Tx, planID int64, count int) ([]int64, error) {
+	ctxt := "ReadingPlanQuery-CreateDays"
+	rows, err := tx.Query(
+		ctx,
+		`INSERT INTO reading_plan_days ("plan_id", "day")
+		SELECT $1, generate_series(1, $2)
+		RETURNING "id", "day"`,
+		planID,
+		count,
+	)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return nil, err
+	}
+	defer rows.Close()
+	response := make([]int64, count)
+	for rows.Next() {
+		var (
+			id  int64
+			day int
+		)
+		if err := rows.Scan(&id, &day); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return nil, err
+		}
+		response[day-1] = id
+	}
+	if err := rows.Err(); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrRows")
+		return nil, err
+	}
+	return response, nil
+}
+
+func (q *readingPlanQuery) CopyPassages(ctx context.Context, tx pgx.Tx, passages []*model.Passage) (int64, error) {
+	ctxt := "ReadingPlanQuery-CopyPassages"
+	count, err := tx.CopyFrom(
+		ctx,
+		pgx.Identifier{"reading_plan_passages"},
+		passageColumns,
+		pgx.CopyFromSlice(len(passages), func(i int) ([]interface{}, error) {
+			passage := passages[i]
+			return []interface{}{passage.DayID, passage.Position, passage.Reference}, nil
+		}),
+	)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCopyFrom")
+	}
+	return count, err
+}
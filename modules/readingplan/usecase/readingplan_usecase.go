@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/jackc/pgx/v5"
+	"github.com/roysitumorang/bible/canon"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/readingplan/model"
+	"github.com/roysitumorang/bible/modules/readingplan/query"
+	scriptureUseCase "github.com/roysitumorang/bible/modules/scripture/usecase"
+	"github.com/roysitumorang/bible/reference"
+	"github.com/roysitumorang/bible/versification"
+	"go.uber.org/zap"
+)
+
+type (
+	readingPlanUseCase struct {
+		readingPlanQuery query.ReadingPlanQuery
+		scriptureUseCase scriptureUseCase.ScriptureUseCase
+		parser           *reference.Parser
+	}
+)
+
+func NewReadingPlanUseCase(
+	readingPlanQuery query.ReadingPlanQuery,
+	scriptureUseCase scriptureUseCase.ScriptureUseCase,
+	registry *canon.Registry,
+) ReadingPlanUseCase {
+	return &readingPlanUseCase{
+		readingPlanQuery: readingPlanQuery,
+		scriptureUseCase: scriptureUseCase,
+		parser:           reference.NewParser(registry),
+	}
+}
+
+func (q *readingPlanUseCase) FindPlans(ctx context.Context) ([]*model.Plan, error) {
+	ctxt := "ReadingPlanUseCase-FindPlans"
+	response, err := q.readingPlanQuery.FindPlans(ctx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindPlans")
+	}
+	return response, err
+}
+
+// FindDay returns the references of a plan day, with their text when
+// translationCode is set
+func (q *readingPlanUseCase) FindDay(ctx context.Context, planCode string, day int, translationCode string) (*model.Day, error) {
+	ctxt := "ReadingPlanUseCase-FindDay"
+	plan, err := q.readingPlanQuery.FindPlanByCode(ctx, strings.ToLower(planCode))
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindPlanByCode")
+		return nil, err
+	}
+	if day < 1 || day > plan.DaysCount {
+		return nil, model.ErrDayNotFound
+	}
+	references, err := q.readingPlanQuery.FindReferences(ctx, plan.ID, day)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindReferences")
+		return nil, err
+	}
+	response := model.Day{
+		Plan:     plan,
+		Day:      day,
+		Readings: make([]*model.Reading, len(references)),
+	}
+	for i, reference := range references {
+		response.Readings[i] = &model.Reading{Reference: reference}
+		if translationCode == "" {
+			continue
+		}
+		if response.Readings[i].Passage, err = q.scriptureUseCase.FindPassage(ctx, translationCode, reference, versification.Standard); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindPassage")
+			return nil, err
+		}
+	}
+	return &response, nil
+}
+
+// Enroll starts userID on the plan at startDate, today when empty
+func (q *readingPlanUseCase) Enroll(ctx context.Context, userID, planCode, startDate string) (*model.Enrollment, error) {
+	ctxt := "ReadingPlanUseCase-Enroll"
+	plan, err := q.readingPlanQuery.FindPlanByCode(ctx, strings.ToLower(planCode))
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindPlanByCode")
+		return nil, err
+	}
+	today := helper.Day(time.Now())
+	start := today
+	if startDate != "" {
+		if start, err = time.Parse(model.DateLayout, startDate); err != nil {
+			return nil, model.ErrInvalidStartDate
+		}
+	}
+	response := model.Enrollment{
+		PlanID:     plan.ID,
+		Plan:       plan.Code,
+		UserID:     userID,
+		StartDate:  start,
+		Start:      start.Format(model.DateLayout),
+		CurrentDay: int(today.Sub(start).Hours()/24) + 1,
+	}
+	if err := q.readingPlanQuery.SaveEnrollment(ctx, &response); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrSaveEnrollment")
+		return nil, err
+	}
+	return &response, nil
+}
+
+// LoadPlans stores every *.json plan found in dir, replacing the days of
+// plans already stored under the same code; each plan is its own
+// transaction
+func (q *readingPlanUseCase) LoadPlans(ctx context.Context, fsys fs.FS, dir string) ([]*model.LoadReport, error) {
+	ctxt := "ReadingPlanUseCase-LoadPlans"
+	paths, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrGlob")
+		return nil, err
+	}
+	response := make([]*model.LoadReport, 0, len(paths))
+	for _, filepath := range paths {
+		content, err := fs.ReadFile(fsys, filepath)
+		if err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrReadFile")
+			return nil, err
+		}
+		var file model.File
+		if err := json.Unmarshal(content, &file); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath, err)
+		}
+		report, err := q.loadPlan(ctx, &file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath, err)
+		}
+		response = append(response, report)
+	}
+	return response, nil
+}
+
+func (q *readingPlanUseCase) loadPlan(ctx context.Context, file *model.File) (*model.LoadReport, error) {
+	ctxt := "ReadingPlanUseCase-loadPlan"
+	plan := model.Plan{
+		Code:      strings.ToLower(strings.TrimSpace(file.Code)),
+		Name:      file.Name,
+		DaysCount: len(file.Days),
+	}
+	if plan.Code == "" || plan.Name == "" || plan.DaysCount == 0 {
+		return nil, model.ErrInvalidPlan
+	}
+	if file.Description != "" {
+		plan.Description = &file.Description
+	}
+	response := model.LoadReport{
+		Plan: plan.Code,
+		Days: plan.DaysCount,
+	}
+	var passages []*model.Passage
+	for i, references := range file.Days {
+		for j, item := range references {
+			ranges, err := q.parser.Parse(item)
+			if err != nil {
+				return nil, fmt.Errorf("day %d: %w", i+1, err)
+			}
+			passages = append(passages, &model.Passage{
+				Position:  j + 1,
+				Reference: reference.Format(ranges),
+			})
+		}
+	}
+	response.Readings = len(passages)
+	tx, err := q.readingPlanQuery.Begin(ctx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrBegin")
+		return nil, err
+	}
+	defer func() {
+		if errRollback := tx.Rollback(ctx); errRollback != nil && !errors.Is(errRollback, pgx.ErrTxClosed) {
+			helper.Capture(ctx, zap.ErrorLevel, errRollback, ctxt, "ErrRollback")
+		}
+	}()
+	if plan.ID, err = q.readingPlanQuery.SavePlan(ctx, tx, &plan); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrSavePlan")
+		return nil, err
+	}
+	if err := q.readingPlanQuery.DeleteDays(ctx, tx, plan.ID); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrDeleteDays")
+		return nil, err
+	}
+	dayIDs, err := q.readingPlanQuery.CreateDays(ctx, tx, plan.ID, plan.DaysCount)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCreateDays")
+		return nil, err
+	}
+	var n int
+	for i, references := range file.Days {
+		for range references {
+			passages[n].DayID = dayIDs[i]
+			n++
+		}
+	}
+	if _, err := q.readingPlanQuery.CopyPassages(ctx, tx, passages); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCopyPassages")
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCommit")
+		return nil, err
+	}
+	return &response, nil
+}
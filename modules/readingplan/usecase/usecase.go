@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+	"io/fs"
+
+	"github.com/roysitumorang/bible/modules/readingplan/model"
+)
+
+type (
+	ReadingPlanUseCase interface {
+		FindPlans(ctx context.Context) ([]*model.Plan, error)
+		FindDay(ctx context.Context, planCode string, day int, translationCode string) (*model.Day, error)
+		Enroll(ctx context.Context, userID, planCode, startDate string) (*model.Enrollment, error)
+		LoadPlans(ctx context.Context, fsys fs.FS, dir string) ([]*model.LoadReport, error)
+	}
+)
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/roysitumorang/bible/canon"
+	"github.com/roysitumorang/bible/models"
+	"github.com/roysitumorang/bible/reference"
+	"github.com/roysitumorang/bible/versification"
+)
+
+type (
+	Translation struct {
+		ID            int64     `json:"-"`
+		Code          string    `json:"code"`
+		Name          string    `json:"name"`
+		Language      string    `json:"language"`
+		Description   *string   `json:"description"`
+		License       *string   `json:"license"`
+		Versification string    `json:"versification"`
+		CreatedAt     time.Time `json:"created_at"`
+		UpdatedAt     time.Time `json:"updated_at"`
+	}
+
+	Book struct {
+		ID            int    `json:"id"`
+		Code          string `json:"code"`
+		USFMCode      string `json:"usfm_code"`
+		Name          string `json:"name"`
+		Abbreviation  string `json:"abbreviation"`
+		Testament     string `json:"testament"`
+		ChaptersCount int    `json:"chapters_count"`
+	}
+
+	Verse struct {
+		ID      int64             `json:"-"`
+		BookID  int               `json:"-"`
+		Book    string            `json:"book"`
+		Chapter int               `json:"chapter"`
+		Verse   int               `json:"verse"`
+		Text    string            `json:"text"`
+		Content []*models.Segment `json:"content,omitempty"`
+	}
+
+	// Chapter holds the verses of Chapter, numbered in Versification, as
+	// Passage does
+	Chapter struct {
+		Translation   *Translation `json:"translation"`
+		Book          *Book        `json:"book"`
+		Chapter       int          `json:"chapter"`
+		Versification string       `json:"versification"`
+		Verses        []*Verse     `json:"verses"`
+	}
+
+	// Passage holds the verses of Reference, numbered in Versification;
+	// they are mapped to the numbering of Translation when both differ
+	Passage struct {
+		Translation   *Translation `json:"translation"`
+		Reference     string       `json:"reference"`
+		Versification string       `json:"versification"`
+		Verses        []*Verse     `json:"verses"`
+	}
+
+	VerseFilter struct {
+		TranslationID int64
+		StartOrdinal  int
+		EndOrdinal    int
+		// at most Limit verses when positive
+		Limit int
+	}
+)
+
+const (
+	TestamentOld = "OT"
+	TestamentNew = "NT"
+
+	// verse ordinals are book_id * 1000000 + chapter * 1000 + verse,
+	// mirroring the generated "ordinal" column of the verses table, whose
+	// checks hold chapter and verse to MaxChapter and MaxVerse
+	ordinalBookFactor    = 1000000
+	ordinalChapterFactor = 1000
+	MaxChapter           = ordinalBookFactor/ordinalChapterFactor - 1
+	MaxVerse             = ordinalChapterFactor - 1
+	MaxOrdinal           = math.MaxInt32
+
+	// budget of a passage, well above the longest daily reading of the
+	// reading plans
+	MaxPassageRanges = 20
+	MaxPassageVerses = 500
+)
+
+var (
+	ErrTranslationNotFound  = errors.New("translation not found")
+	ErrBookNotFound         = errors.New("book not found")
+	ErrChapterNotFound      = errors.New("chapter not found")
+	ErrVerseNotFound        = errors.New("verse not found")
+	ErrUnknownVersification = versification.ErrUnknownScheme
+	ErrPassageTooLong       = errors.New("ref: at most 20 ranges and 500 verses")
+)
+
+func Ordinal(bookID, chapter, verse int) int {
+	return bookID*ordinalBookFactor + chapter*ordinalChapterFactor + verse
+}
+
+// RangeOrdinals returns the ordinals bounding a parsed range; a range
+// ending on a whole chapter ends at its highest possible verse
+func RangeOrdinals(registry *canon.Registry, r reference.Range) (start, end int) {
+	startBook, _ := registry.Book(r.Start.Book)
+	endBook, _ := registry.Book(r.End.Book)
+	endVerse := r.End.Verse
+	if endVerse == 0 {
+		endVerse = MaxVerse
+	}
+	return Ordinal(startBook.ID, r.Start.Chapter, r.Start.Verse), Ordinal(endBook.ID, r.End.Chapter, endVerse)
+}
+
+// SplitOrdinal is the inverse of Ordinal
+func SplitOrdinal(ordinal int) (bookID, chapter, verse int) {
+	return ordinal / ordinalBookFactor, ordinal % ordinalBookFactor / ordinalChapterFactor, ordinal % ordinalChapterFactor
+}
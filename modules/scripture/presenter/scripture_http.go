@@ -0,0 +1,121 @@
+package presenter
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/scripture/model"
+	"github.com/roysitumorang/bible/modules/scripture/usecase"
+	"github.com/roysitumorang/bible/reference"
+	"go.uber.org/zap"
+)
+
+type (
+	ScriptureHTTPHandler struct {
+		scriptureUseCase usecase.ScriptureUseCase
+	}
+)
+
+func NewScriptureHTTPHandler(scriptureUseCase usecase.ScriptureUseCase) *ScriptureHTTPHandler {
+	return &ScriptureHTTPHandler{
+		scriptureUseCase: scriptureUseCase,
+	}
+}
+
+func (q *ScriptureHTTPHandler) Mount(r fiber.Router) {
+	r.Get("/books", q.FindBooks)
+	translations := r.Group("/translations")
+	translations.Get("", q.FindTranslations).
+		Get("/:translation/books", q.FindBooks).
+		Get("/:translation/books/:book/chapters/:chapter", q.FindChapter).
+		Get("/:translation/books/:book/chapters/:chapter/verses/:verse", q.FindVerse).
+		Get("/:translation/passages", q.FindPassage)
+}
+
+func (q *ScriptureHTTPHandler) FindTranslations(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "ScriptureHTTPHandler-FindTranslations"
+	response, err := q.scriptureUseCase.FindTranslations(ctx)
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrFindTranslations")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(fiber.StatusOK, "", response).WriteResponse(c)
+}
+
+func (q *ScriptureHTTPHandler) FindBooks(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "ScriptureHTTPHandler-FindBooks"
+	response, err := q.scriptureUseCase.FindBooks(ctx, c.Params("translation"), c.Query("lang"))
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrFindBooks")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(fiber.StatusOK, "", response).WriteResponse(c)
+}
+
+func (q *ScriptureHTTPHandler) FindChapter(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "ScriptureHTTPHandler-FindChapter"
+	chapter, err := c.ParamsInt("chapter")
+	if err != nil || chapter < 1 {
+		return helper.NewResponse(fiber.StatusBadRequest, "chapter: positive integer required", nil).WriteResponse(c)
+	}
+	response, err := q.scriptureUseCase.FindChapter(ctx, c.Params("translation"), c.Params("book"), chapter, c.Query("versification"))
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrFindChapter")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(fiber.StatusOK, "", response).WriteResponse(c)
+}
+
+func (q *ScriptureHTTPHandler) FindVerse(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "ScriptureHTTPHandler-FindVerse"
+	chapter, err := c.ParamsInt("chapter")
+	if err != nil || chapter < 1 {
+		return helper.NewResponse(fiber.StatusBadRequest, "chapter: positive integer required", nil).WriteResponse(c)
+	}
+	verse, err := c.ParamsInt("verse")
+	if err != nil || verse < 1 {
+		return helper.NewResponse(fiber.StatusBadRequest, "verse: positive integer required", nil).WriteResponse(c)
+	}
+	response, err := q.scriptureUseCase.FindVerse(ctx, c.Params("translation"), c.Params("book"), chapter, verse, c.Query("versification"))
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrFindVerse")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(fiber.StatusOK, "", response).WriteResponse(c)
+}
+
+func (q *ScriptureHTTPHandler) FindPassage(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "ScriptureHTTPHandler-FindPassage"
+	ref := c.Query("ref")
+	if ref == "" {
+		return helper.NewResponse(fiber.StatusBadRequest, "ref: required", nil).WriteResponse(c)
+	}
+	response, err := q.scriptureUseCase.FindPassage(ctx, c.Params("translation"), ref, c.Query("versification"))
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrFindPassage")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(fiber.StatusOK, "", response).WriteResponse(c)
+}
+
+func statusCode(err error) int {
+	var parseErr *reference.ParseError
+	switch {
+	case errors.Is(err, model.ErrTranslationNotFound),
+		errors.Is(err, model.ErrBookNotFound),
+		errors.Is(err, model.ErrChapterNotFound),
+		errors.Is(err, model.ErrVerseNotFound):
+		return fiber.StatusNotFound
+	case errors.As(err, &parseErr),
+		errors.Is(err, model.ErrUnknownVersification),
+		errors.Is(err, model.ErrPassageTooLong):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
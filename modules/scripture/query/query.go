@@ -0,0 +1,21 @@
+package query
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/canon"
+	"github.com/roysitumorang/bible/modules/scripture/model"
+)
+
+type (
+	ScriptureQuery interface {
+		FindTranslations(ctx context.Context) ([]*model.Translation, error)
+		FindTranslationByCode(ctx context.Context, code string) (*model.Translation, error)
+		FindBooks(ctx context.Context, translationID int64) ([]*model.Book, error)
+		FindBookByCode(ctx context.Context, code string) (*model.Book, error)
+		FindVerses(ctx context.Context, filter *model.VerseFilter) ([]*model.Verse, error)
+		StreamVerses(ctx context.Context, filter *model.VerseFilter, fn func(*model.Verse) error) error
+		FindVersesInRanges(ctx context.Context, translationID int64, startOrdinals, endOrdinals []int) ([][]*model.Verse, error)
+		FindBookNames(ctx context.Context) ([]*canon.Name, error)
+	}
+)
@@ -0,0 +1,320 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/roysitumorang/bible/canon"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/scripture/model"
+	"go.uber.org/zap"
+)
+
+type (
+	scriptureQuery struct {
+		dbRead *pgxpool.Pool
+	}
+)
+
+func NewScriptureQuery(dbRead *pgxpool.Pool) ScriptureQuery {
+	return &scriptureQuery{
+		dbRead: dbRead,
+	}
+}
+
+func (q *scriptureQuery) FindTranslations(ctx context.Context) ([]*model.Translation, error) {
+	ctxt := "ScriptureQuery-FindTranslations"
+	rows, err := q.dbRead.Query(
+		ctx,
+		`SELECT "id", "code", "name", "language", "description", "license", "versification", "created_at", "updated_at"
+		FROM translations
+		ORDER BY "language", "code"`,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return nil, err
+	}
+	defer rows.Close()
+	var response []*model.Translation
+	for rows.Next() {
+		var translation model.Translation
+		if err := rows.Scan(
+			&translation.ID,
+			&translation.Code,
+			&translation.Name,
+			&translation.Language,
+			&translation.Description,
+			&translation.License,
+			&translation.Versification,
+			&translation.CreatedAt,
+			&translation.UpdatedAt,
+		); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return nil, err
+		}
+		response = append(response, &translation)
+	}
+	return response, nil
+}
+
+func (q *scriptureQuery) FindTranslationByCode(ctx context.Context, code string) (*model.Translation, error) {
+	ctxt := "ScriptureQuery-FindTranslationByCode"
+	var response model.Translation
+	err := q.dbRead.QueryRow(
+		ctx,
+		`SELECT "id", "code", "name", "language", "description", "license", "versification", "created_at", "updated_at"
+		FROM translations
+		WHERE "code" = $1`,
+		strings.ToLower(code),
+	).Scan(
+		&response.ID,
+		&response.Code,
+		&response.Name,
+		&response.Language,
+		&response.Description,
+		&response.License,
+		&response.Versification,
+		&response.CreatedAt,
+		&response.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, model.ErrTranslationNotFound
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (q *scriptureQuery) FindBooks(ctx context.Context, translationID int64) ([]*model.Book, error) {
+	ctxt := "ScriptureQuery-FindBooks"
+	var builder strings.Builder
+	_, _ = builder.WriteString(
+		`SELECT b."id", b."code", b."usfm_code", b."name", b."testament", b."chapters_count"
+		FROM books b`,
+	)
+	var params []interface{}
+	if translationID > 0 {
+		params = append(params, translationID)
+		_, _ = builder.WriteString(
+			` WHERE EXISTS (
+				SELECT 1
+				FROM verses v
+				WHERE v."translation_id" = $1
+					AND v."book_id" = b."id"
+			)`,
+		)
+	}
+	_, _ = builder.WriteString(` ORDER BY b."id"`)
+	rows, err := q.dbRead.Query(ctx, builder.String(), params...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return nil, err
+	}
+	defer rows.Close()
+	var response []*model.Book
+	for rows.Next() {
+		var book model.Book
+		if err := rows.Scan(
+			&book.ID,
+			&book.Code,
+			&book.USFMCode,
+			&book.Name,
+			&book.Testament,
+			&book.ChaptersCount,
+		); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return nil, err
+		}
+		response = append(response, &book)
+	}
+	return response, nil
+}
+
+func (q *scriptureQuery) FindBookByCode(ctx context.Context, code string) (*model.Book, error) {
+	ctxt := "ScriptureQuery-FindBookByCode"
+	var response model.Book
+	err := q.dbRead.QueryRow(
+		ctx,
+		`SELECT "id", "code", "usfm_code", "name", "testament", "chapters_count"
+		FROM books
+		WHERE LOWER("code") = LOWER($1)
+			OR "usfm_code" = UPPER($1)`,
+		code,
+	).Scan(
+		&response.ID,
+		&response.Code,
+		&response.USFMCode,
+		&response.Name,
+		&response.Testament,
+		&response.ChaptersCount,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, model.ErrBookNotFound
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (q *scriptureQuery) FindVerses(ctx context.Context, filter *model.VerseFilter) ([]*model.Verse, error) {
+	ctxt := "ScriptureQuery-FindVerses"
+	var response []*model.Verse
+	if err := q.StreamVerses(ctx, filter, func(verse *model.Verse) error {
+		response = append(response, verse)
+		return nil
+	}); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrStreamVerses")
+		return nil, err
+	}
+	return response, nil
+}
+
+// StreamVerses hands the verses to fn as rows arrive, so callers such as
+// the exporter never hold a whole translation in memory
+func (q *scriptureQuery) StreamVerses(ctx context.Context, filter *model.VerseFilter, fn func(*model.Verse) error) error {
+	ctxt := "ScriptureQuery-StreamVerses"
+	params := []interface{}{filter.TranslationID, filter.StartOrdinal, filter.EndOrdinal}
+	query := `SELECT v."id", v."book_id", b."code", v."chapter", v."verse", v."text", v."content"
+		FROM verses v
+		JOIN books b ON b."id" = v."book_id"
+		WHERE v."translation_id" = $1
+			AND v."ordinal" BETWEEN $2 AND $3
+		ORDER BY v."ordinal"`
+	if filter.Limit > 0 {
+		params = append(params, filter.Limit)
+		query += ` LIMIT $4`
+	}
+	rows, err := q.dbRead.Query(ctx, query, params...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var verse model.Verse
+		if err := rows.Scan(
+			&verse.ID,
+			&verse.BookID,
+			&verse.Book,
+			&verse.Chapter,
+			&verse.Verse,
+			&verse.Text,
+			&verse.Content,
+		); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return err
+		}
+		if err := fn(&verse); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrErr")
+		return err
+	}
+	return nil
+}
+
+// FindVersesInRanges reads the verses of every range
+// startOrdinals[i]-endOrdinals[i] in a single query, response[i] holding
+// the ones of range i in canonical order
+func (q *scriptureQuery) FindVersesInRanges(ctx context.Context, translationID int64, startOrdinals, endOrdinals []int) ([][]*model.Verse, error) {
+	ctxt := "ScriptureQuery-FindVersesInRanges"
+	rows, err := q.dbRead.Query(
+		ctx,
+		`SELECT r."index", v."id", v."book_id", b."code", v."chapter", v."verse", v."text", v."content"
+		FROM UNNEST($2::integer[], $3::integer[]) WITH ORDINALITY r("start_ordinal", "end_ordinal", "index")
+		JOIN verses v ON v."translation_id" = $1 AND v."ordinal" BETWEEN r."start_ordinal" AND r."end_ordinal"
+		JOIN books b ON b."id" = v."book_id"
+		ORDER BY r."index", v."ordinal"`,
+		translationID,
+		startOrdinals,
+		endOrdinals,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return nil, err
+	}
+	defer rows.Close()
+	response := make([][]*model.Verse, len(startOrdinals))
+	for rows.Next() {
+		var (
+			index int
+			verse model.Verse
+		)
+		if err := rows.Scan(
+			&index,
+			&verse.ID,
+			&verse.BookID,
+			&verse.Book,
+			&verse.Chapter,
+			&verse.Verse,
+			&verse.Text,
+			&verse.Content,
+		); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return nil, err
+		}
+		// WITH ORDINALITY counts from 1
+		response[index-1] = append(response[index-1], &verse)
+	}
+	if err := rows.Err(); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrErr")
+		return nil, err
+	}
+	return response, nil
+}
+
+func (q *scriptureQuery) FindBookNames(ctx context.Context) ([]*canon.Name, error) {
+	ctxt := "ScriptureQuery-FindBookNames"
+	rows, err := q.dbRead.Query(
+		ctx,
+		`SELECT b."code", bn."language", bn."name", bn."abbreviation", bn."aliases"
+		FROM book_names bn
+		JOIN books b ON b."id" = bn."book_id"
+		ORDER BY bn."language", b."id"`,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return nil, err
+	}
+	defer rows.Close()
+	var response []*canon.Name
+	for rows.Next() {
+		var name canon.Name
+		if err := rows.Scan(
+			&name.Code,
+			&name.Language,
+			&name.Name,
+			&name.Abbreviation,
+			&name.Aliases,
+		); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return nil, err
+		}
+		response = append(response, &name)
+	}
+	return response, nil
+}
@@ -0,0 +1,340 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/roysitumorang/bible/canon"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/scripture/model"
+	"github.com/roysitumorang/bible/modules/scripture/query"
+	"github.com/roysitumorang/bible/reference"
+	"github.com/roysitumorang/bible/versification"
+	"go.uber.org/zap"
+)
+
+type (
+	scriptureUseCase struct {
+		scriptureQuery query.ScriptureQuery
+		registry       *canon.Registry
+		versifications *versification.Registry
+		parser         *reference.Parser
+	}
+)
+
+func NewScriptureUseCase(scriptureQuery query.ScriptureQuery, registry *canon.Registry, versifications *versification.Registry) ScriptureUseCase {
+	return &scriptureUseCase{
+		scriptureQuery: scriptureQuery,
+		registry:       registry,
+		versifications: versifications,
+		parser:         reference.NewParser(registry),
+	}
+}
+
+// LoadBookNames adds the localized book names stored in the database to
+// the registry, on top of the embedded ones
+func (q *scriptureUseCase) LoadBookNames(ctx context.Context) error {
+	ctxt := "ScriptureUseCase-LoadBookNames"
+	names, err := q.scriptureQuery.FindBookNames(ctx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindBookNames")
+		return err
+	}
+	if err := q.registry.Add(names...); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrAdd")
+		return err
+	}
+	return nil
+}
+
+func (q *scriptureUseCase) FindTranslations(ctx context.Context) ([]*model.Translation, error) {
+	ctxt := "ScriptureUseCase-FindTranslations"
+	response, err := q.scriptureQuery.FindTranslations(ctx)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindTranslations")
+	}
+	return response, err
+}
+
+func (q *scriptureUseCase) FindBooks(ctx context.Context, translationCode, language string) ([]*model.Book, error) {
+	ctxt := "ScriptureUseCase-FindBooks"
+	var translationID int64
+	if translationCode != "" {
+		translation, err := q.scriptureQuery.FindTranslationByCode(ctx, translationCode)
+		if err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindTranslationByCode")
+			return nil, err
+		}
+		translationID = translation.ID
+		if language == "" {
+			language = translation.Language
+		}
+	}
+	response, err := q.scriptureQuery.FindBooks(ctx, translationID)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindBooks")
+		return nil, err
+	}
+	for _, book := range response {
+		q.localizeBook(book, language)
+	}
+	return response, nil
+}
+
+// FindChapter reads chapter as numbered in versificationCode, the
+// numbering of the translation when empty
+func (q *scriptureUseCase) FindChapter(ctx context.Context, translationCode, bookCode string, chapter int, versificationCode string) (*model.Chapter, error) {
+	ctxt := "ScriptureUseCase-FindChapter"
+	translation, err := q.scriptureQuery.FindTranslationByCode(ctx, translationCode)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindTranslationByCode")
+		return nil, err
+	}
+	if versificationCode, err = q.resolveVersification(translation, versificationCode); err != nil {
+		return nil, err
+	}
+	book, err := q.scriptureQuery.FindBookByCode(ctx, bookCode)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindBookByCode")
+		return nil, err
+	}
+	if chapter > maxChapter(book, versificationCode) {
+		return nil, model.ErrChapterNotFound
+	}
+	verses, err := q.findMappedVerses(
+		ctx,
+		&model.VerseFilter{
+			TranslationID: translation.ID,
+			StartOrdinal:  model.Ordinal(book.ID, chapter, 0),
+			EndOrdinal:    model.Ordinal(book.ID, chapter, model.MaxVerse),
+		},
+		versificationCode,
+		translation.Versification,
+	)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindMappedVerses")
+		return nil, err
+	}
+	if len(verses) == 0 {
+		return nil, model.ErrChapterNotFound
+	}
+	q.localizeBook(book, translation.Language)
+	return &model.Chapter{
+		Translation:   translation,
+		Book:          book,
+		Chapter:       chapter,
+		Versification: versificationCode,
+		Verses:        verses,
+	}, nil
+}
+
+// FindVerse reads chapter and verse as numbered in versificationCode, the
+// numbering of the translation when empty, returning the first verse they
+// map to
+func (q *scriptureUseCase) FindVerse(ctx context.Context, translationCode, bookCode string, chapter, verse int, versificationCode string) (*model.Verse, error) {
+	ctxt := "ScriptureUseCase-FindVerse"
+	if verse > model.MaxVerse {
+		return nil, model.ErrVerseNotFound
+	}
+	translation, err := q.scriptureQuery.FindTranslationByCode(ctx, translationCode)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindTranslationByCode")
+		return nil, err
+	}
+	if versificationCode, err = q.resolveVersification(translation, versificationCode); err != nil {
+		return nil, err
+	}
+	book, err := q.scriptureQuery.FindBookByCode(ctx, bookCode)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindBookByCode")
+		return nil, err
+	}
+	if chapter > maxChapter(book, versificationCode) {
+		return nil, model.ErrChapterNotFound
+	}
+	ordinal := model.Ordinal(book.ID, chapter, verse)
+	verses, err := q.findMappedVerses(
+		ctx,
+		&model.VerseFilter{
+			TranslationID: translation.ID,
+			StartOrdinal:  ordinal,
+			EndOrdinal:    ordinal,
+		},
+		versificationCode,
+		translation.Versification,
+	)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindMappedVerses")
+		return nil, err
+	}
+	if len(verses) == 0 {
+		return nil, model.ErrVerseNotFound
+	}
+	return verses[0], nil
+}
+
+// FindPassage reads ref as numbered in versificationCode, the numbering
+// of the translation when empty, up to model.MaxPassageVerses verses
+func (q *scriptureUseCase) FindPassage(ctx context.Context, translationCode, ref, versificationCode string) (*model.Passage, error) {
+	ctxt := "ScriptureUseCase-FindPassage"
+	ranges, err := q.parser.Parse(ref)
+	if err != nil {
+		return nil, err
+	}
+	if len(ranges) > model.MaxPassageRanges {
+		return nil, model.ErrPassageTooLong
+	}
+	translation, err := q.scriptureQuery.FindTranslationByCode(ctx, translationCode)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindTranslationByCode")
+		return nil, err
+	}
+	if versificationCode, err = q.resolveVersification(translation, versificationCode); err != nil {
+		return nil, err
+	}
+	response := model.Passage{
+		Translation:   translation,
+		Reference:     reference.Format(ranges),
+		Versification: versificationCode,
+	}
+	for _, r := range ranges {
+		filter := model.VerseFilter{
+			TranslationID: translation.ID,
+			// one more than the budget tells it is exceeded
+			Limit: model.MaxPassageVerses + 1 - len(response.Verses),
+		}
+		filter.StartOrdinal, filter.EndOrdinal = model.RangeOrdinals(q.registry, r)
+		verses, err := q.findMappedVerses(ctx, &filter, versificationCode, translation.Versification)
+		if err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindMappedVerses")
+			return nil, err
+		}
+		if response.Verses = append(response.Verses, verses...); len(response.Verses) > model.MaxPassageVerses {
+			return nil, model.ErrPassageTooLong
+		}
+	}
+	if len(response.Verses) == 0 {
+		return nil, model.ErrVerseNotFound
+	}
+	return &response, nil
+}
+
+// FindVersesInRanges returns the verses of each of ranges, ordinals
+// numbered in versificationCode, read from the translation in a single
+// query; response[i] is empty when the translation lacks range i
+func (q *scriptureUseCase) FindVersesInRanges(ctx context.Context, translationCode, versificationCode string, ranges []versification.Interval) ([][]*model.Verse, error) {
+	ctxt := "ScriptureUseCase-FindVersesInRanges"
+	translation, err := q.scriptureQuery.FindTranslationByCode(ctx, translationCode)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindTranslationByCode")
+		return nil, err
+	}
+	if versificationCode, err = q.resolveVersification(translation, versificationCode); err != nil {
+		return nil, err
+	}
+	var (
+		startOrdinals, endOrdinals []int
+		// the range of ranges each mapped interval comes from
+		owners []int
+	)
+	for i, r := range ranges {
+		intervals, err := q.versifications.Map(versificationCode, translation.Versification, r.Start, r.End)
+		if err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrMap")
+			return nil, err
+		}
+		for _, interval := range intervals {
+			startOrdinals = append(startOrdinals, interval.Start)
+			endOrdinals = append(endOrdinals, interval.End)
+			owners = append(owners, i)
+		}
+	}
+	response := make([][]*model.Verse, len(ranges))
+	if len(owners) == 0 {
+		return response, nil
+	}
+	verses, err := q.scriptureQuery.FindVersesInRanges(ctx, translation.ID, startOrdinals, endOrdinals)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindVersesInRanges")
+		return nil, err
+	}
+	for i, owner := range owners {
+		response[owner] = append(response[owner], verses[i]...)
+	}
+	return response, nil
+}
+
+// resolveVersification checks versificationCode, defaulting to the
+// numbering of translation
+func (q *scriptureUseCase) resolveVersification(translation *model.Translation, versificationCode string) (string, error) {
+	if versificationCode = strings.ToLower(versificationCode); versificationCode == "" {
+		versificationCode = translation.Versification
+	}
+	if _, ok := q.versifications.Scheme(versificationCode); !ok {
+		return "", fmt.Errorf("%w: %s", model.ErrUnknownVersification, versificationCode)
+	}
+	return versificationCode, nil
+}
+
+// findMappedVerses returns the verses of filter, numbered in from, as
+// stored in the numbering to of the translation, at most filter.Limit of
+// them when positive
+func (q *scriptureUseCase) findMappedVerses(ctx context.Context, filter *model.VerseFilter, from, to string) ([]*model.Verse, error) {
+	ctxt := "ScriptureUseCase-findMappedVerses"
+	filters, err := q.mapVerseFilter(filter, from, to)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrMapVerseFilter")
+		return nil, err
+	}
+	var response []*model.Verse
+	for _, mapped := range filters {
+		if filter.Limit > 0 {
+			if mapped.Limit = filter.Limit - len(response); mapped.Limit <= 0 {
+				break
+			}
+		}
+		verses, err := q.scriptureQuery.FindVerses(ctx, mapped)
+		if err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindVerses")
+			return nil, err
+		}
+		response = append(response, verses...)
+	}
+	return response, nil
+}
+
+// mapVerseFilter renumbers filter from one versification to another; a
+// range may end up in several pieces (Malachi 4 is Malachi 3:19-24 in the
+// Hebrew numbering)
+func (q *scriptureUseCase) mapVerseFilter(filter *model.VerseFilter, from, to string) ([]*model.VerseFilter, error) {
+	intervals, err := q.versifications.Map(from, to, filter.StartOrdinal, filter.EndOrdinal)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]*model.VerseFilter, len(intervals))
+	for i, interval := range intervals {
+		response[i] = &model.VerseFilter{
+			TranslationID: filter.TranslationID,
+			StartOrdinal:  interval.Start,
+			EndOrdinal:    interval.End,
+		}
+	}
+	return response, nil
+}
+
+func (q *scriptureUseCase) localizeBook(book *model.Book, language string) {
+	name := q.registry.Name(book.Code, language)
+	book.Name, book.Abbreviation = name.Name, name.Abbreviation
+}
+
+// maxChapter bounds the chapters of book as numbered in versificationCode:
+// its chapter count is that of the standard numbering, others only have
+// to stay within the ordinals of the book (Joel has 4 chapters in the
+// Hebrew numbering)
+func maxChapter(book *model.Book, versificationCode string) int {
+	if versificationCode == versification.Standard {
+		return min(book.ChaptersCount, model.MaxChapter)
+	}
+	return model.MaxChapter
+}
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/modules/scripture/model"
+	"github.com/roysitumorang/bible/versification"
+)
+
+type (
+	ScriptureUseCase interface {
+		FindTranslations(ctx context.Context) ([]*model.Translation, error)
+		LoadBookNames(ctx context.Context) error
+		FindBooks(ctx context.Context, translationCode, language string) ([]*model.Book, error)
+		FindChapter(ctx context.Context, translationCode, bookCode string, chapter int, versificationCode string) (*model.Chapter, error)
+		FindVerse(ctx context.Context, translationCode, bookCode string, chapter, verse int, versificationCode string) (*model.Verse, error)
+		FindPassage(ctx context.Context, translationCode, ref, versificationCode string) (*model.Passage, error)
+		FindVersesInRanges(ctx context.Context, translationCode, versificationCode string, ranges []versification.Interval) ([][]*model.Verse, error)
+	}
+)
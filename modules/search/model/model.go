@@ -0,0 +1,66 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/roysitumorang/bible/models"
+)
+
+type (
+	Filter struct {
+		Query        string
+		Translations []string
+		Testament    string
+		FromBookID   int
+		ToBookID     int
+		Sort         string
+		Page         int
+		PerPage      int
+		// Keyset pages after After (from the first result when nil)
+		// instead of by Page
+		Keyset bool
+		After  *Cursor
+	}
+
+	// Cursor is the sort key of the last result of a keyset page
+	Cursor struct {
+		Rank        float32
+		Ordinal     int
+		Translation string
+	}
+
+	Result struct {
+		Ordinal     int     `json:"-"`
+		Translation string  `json:"translation"`
+		Book        string  `json:"book"`
+		Chapter     int     `json:"chapter"`
+		Verse       int     `json:"verse"`
+		Text        string  `json:"text"`
+		Highlight   string  `json:"highlight"`
+		Rank        float32 `json:"rank"`
+	}
+
+	Response struct {
+		Pagination *models.Pagination `json:"pagination"`
+		Results    []*Result          `json:"results"`
+	}
+
+	CursorResponse struct {
+		Pagination *models.CursorPagination `json:"pagination"`
+		Results    []*Result                `json:"results"`
+	}
+)
+
+const (
+	SortRelevance = "relevance"
+	SortCanonical = "canonical"
+
+	DefaultPerPage = 10
+)
+
+var (
+	ErrQueryRequired    = errors.New("q: required")
+	ErrInvalidTestament = errors.New("testament: expected OT or NT")
+	ErrInvalidSort      = errors.New("sort: expected relevance or canonical")
+	ErrUnknownBook      = errors.New("unknown book")
+)
@@ -0,0 +1,101 @@
+package presenter
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/models"
+	"github.com/roysitumorang/bible/modules/search/model"
+	"github.com/roysitumorang/bible/modules/search/usecase"
+	"go.uber.org/zap"
+)
+
+type (
+	SearchHTTPHandler struct {
+		searchUseCase usecase.SearchUseCase
+	}
+)
+
+func NewSearchHTTPHandler(searchUseCase usecase.SearchUseCase) *SearchHTTPHandler {
+	return &SearchHTTPHandler{
+		searchUseCase: searchUseCase,
+	}
+}
+
+func (q *SearchHTTPHandler) Mount(r fiber.Router) {
+	r.Get("", q.Search)
+}
+
+// Search handles GET /v1/search?q=kasih&translation=tb,kjv&testament=NT&from=Matt&to=John&sort=relevance&page=1&per_page=10,
+// paging by keyset instead when a cursor parameter is given, empty for
+// the first page (&cursor=&per_page=10)
+func (q *SearchHTTPHandler) Search(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "SearchHTTPHandler-Search"
+	filter := model.Filter{
+		Query:     c.Query("q"),
+		Testament: c.Query("testament"),
+		Sort:      c.Query("sort"),
+	}
+	if translations := c.Query("translation"); translations != "" {
+		filter.Translations = strings.Split(translations, ",")
+	}
+	var err error
+	if from := c.Query("from"); from != "" {
+		if filter.FromBookID, err = q.searchUseCase.ResolveBook(from); err != nil {
+			return helper.NewResponse(fiber.StatusBadRequest, err.Error(), nil).WriteResponse(c)
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		if filter.ToBookID, err = q.searchUseCase.ResolveBook(to); err != nil {
+			return helper.NewResponse(fiber.StatusBadRequest, err.Error(), nil).WriteResponse(c)
+		}
+	}
+	if c.Request().URI().QueryArgs().Has("cursor") {
+		if filter.PerPage, err = helper.ParsePerPage(c, model.DefaultPerPage); err != nil {
+			return helper.NewResponse(fiber.StatusBadRequest, err.Error(), nil).WriteResponse(c)
+		}
+		results, next, err := q.searchUseCase.SearchAfter(ctx, &filter, c.Query("cursor"))
+		if err != nil {
+			helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrSearchAfter")
+			return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+		}
+		return helper.NewResponse(
+			fiber.StatusOK,
+			"",
+			&model.CursorResponse{
+				Pagination: helper.NewCursorPagination(c, filter.PerPage, next),
+				Results:    results,
+			},
+		).WriteResponse(c)
+	}
+	if filter.Page, filter.PerPage, err = helper.ParsePage(c, model.DefaultPerPage); err != nil {
+		return helper.NewResponse(fiber.StatusBadRequest, err.Error(), nil).WriteResponse(c)
+	}
+	results, total, err := q.searchUseCase.Search(ctx, &filter)
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrSearch")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(
+		fiber.StatusOK,
+		"",
+		&model.Response{
+			Pagination: helper.NewPagination(c, filter.Page, filter.PerPage, total),
+			Results:    results,
+		},
+	).WriteResponse(c)
+}
+
+func statusCode(err error) int {
+	if errors.Is(err, model.ErrQueryRequired) ||
+		errors.Is(err, model.ErrInvalidTestament) ||
+		errors.Is(err, model.ErrInvalidSort) ||
+		errors.Is(err, models.ErrInvalidCursor) ||
+		errors.Is(err, model.ErrUnknownBook) {
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
@@ -0,0 +1,14 @@
+package query
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/modules/search/model"
+)
+
+type (
+	SearchQuery interface {
+		FindVerses(ctx context.Context, filter *model.Filter) ([]*model.Result, error)
+		CountVerses(ctx context.Context, filter *model.Filter) (int, error)
+	}
+)
@@ -0,0 +1,166 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/search/model"
+	"go.uber.org/zap"
+)
+
+type (
+	searchQuery struct {
+		dbRead *pgxpool.Pool
+	}
+)
+
+const (
+	// the query is parsed with each translation's own text search
+	// configuration, so stemming follows the language of the text
+	searchFrom = `FROM verses v
+		JOIN translations t ON t."id" = v."translation_id"
+		JOIN books b ON b."id" = v."book_id"
+		CROSS JOIN LATERAL websearch_to_tsquery(t."text_search_config", $1) q("query")
+		WHERE v."search_vector" @@ q."query"`
+
+	// ts_headline marks the matches with these private use characters,
+	// dropped from the verse text beforehand, so the text can be escaped
+	// before they turn into <mark> tags
+	highlightStart = "\uE000"
+	highlightStop  = "\uE001"
+)
+
+var (
+	highlightReplacer = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+)
+
+func NewSearchQuery(dbRead *pgxpool.Pool) SearchQuery {
+	return &searchQuery{
+		dbRead: dbRead,
+	}
+}
+
+func (q *searchQuery) FindVerses(ctx context.Context, filter *model.Filter) ([]*model.Result, error) {
+	ctxt := "SearchQuery-FindVerses"
+	conditions, params := q.conditions(filter)
+	orderBy := `7 DESC, v."ordinal", t."code"`
+	if filter.Sort == model.SortCanonical {
+		orderBy = `v."ordinal", t."code"`
+	}
+	limit, offset := filter.PerPage, helper.Offset(filter.Page, filter.PerPage)
+	if filter.Keyset {
+		// one result more than the page tells whether another one follows
+		limit, offset = filter.PerPage+1, 0
+		if after := filter.After; after != nil {
+			params = append(params, after.Ordinal, after.Translation)
+			keyset := fmt.Sprintf(`(v."ordinal", t."code") > ($%d, $%d)`, len(params)-1, len(params))
+			if filter.Sort == model.SortRelevance {
+				params = append(params, after.Rank)
+				keyset = fmt.Sprintf(
+					`(ts_rank(v."search_vector", q."query") < $%d OR ts_rank(v."search_vector", q."query") = $%d AND %s)`,
+					len(params),
+					len(params),
+					keyset,
+				)
+			}
+			conditions += " AND " + keyset
+		}
+	}
+	params = append(params, limit, offset)
+	rows, err := q.dbRead.Query(
+		ctx,
+		fmt.Sprintf(
+			`SELECT t."code", b."code", v."chapter", v."verse", v."text"
+				, ts_headline(
+					t."text_search_config"
+					, translate(v."text", '%[1]s%[2]s', '')
+					, q."query"
+					, 'StartSel="%[1]s", StopSel="%[2]s", HighlightAll=true'
+				)
+				, ts_rank(v."search_vector", q."query")
+				, v."ordinal"
+			%[3]s%[4]s
+			ORDER BY %[5]s
+			LIMIT $%[6]d OFFSET $%[7]d`,
+			highlightStart,
+			highlightStop,
+			searchFrom,
+			conditions,
+			orderBy,
+			len(params)-1,
+			len(params),
+		),
+		params...,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return nil, err
+	}
+	defer rows.Close()
+	var response []*model.Result
+	for rows.Next() {
+		var result model.Result
+		if err := rows.Scan(
+			&result.Translation,
+			&result.Book,
+			&result.Chapter,
+			&result.Verse,
+			&result.Text,
+			&result.Highlight,
+			&result.Rank,
+			&result.Ordinal,
+		); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return nil, err
+		}
+		result.Highlight = highlightReplacer.Replace(html.EscapeString(result.Highlight))
+		response = append(response, &result)
+	}
+	return response, nil
+}
+
+func (q *searchQuery) CountVerses(ctx context.Context, filter *model.Filter) (int, error) {
+	ctxt := "SearchQuery-CountVerses"
+	conditions, params := q.conditions(filter)
+	var count int
+	if err := q.dbRead.QueryRow(
+		ctx,
+		fmt.Sprintf(`SELECT COUNT(1) %s%s`, searchFrom, conditions),
+		params...,
+	).Scan(&count); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return 0, err
+	}
+	return count, nil
+}
+
+func (q *searchQuery) conditions(filter *model.Filter) (string, []interface{}) {
+	var builder strings.Builder
+	params := []interface{}{filter.Query}
+	if len(filter.Translations) > 0 {
+		params = append(params, filter.Translations)
+		_, _ = fmt.Fprintf(&builder, ` AND t."code" = ANY($%d)`, len(params))
+	}
+	if filter.Testament != "" {
+		params = append(params, filter.Testament)
+		_, _ = fmt.Fprintf(&builder, ` AND b."testament" = $%d`, len(params))
+	}
+	if filter.FromBookID > 0 {
+		params = append(params, filter.FromBookID)
+		_, _ = fmt.Fprintf(&builder, ` AND v."book_id" >= $%d`, len(params))
+	}
+	if filter.ToBookID > 0 {
+		params = append(params, filter.ToBookID)
+		_, _ = fmt.Fprintf(&builder, ` AND v."book_id" <= $%d`, len(params))
+	}
+	return builder.String(), params
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/roysitumorang/bible/canon"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/search/model"
+	"github.com/roysitumorang/bible/modules/search/query"
+	"go.uber.org/zap"
+)
+
+type (
+	searchUseCase struct {
+		searchQuery query.SearchQuery
+		registry    *canon.Registry
+	}
+)
+
+func NewSearchUseCase(searchQuery query.SearchQuery, registry *canon.Registry) SearchUseCase {
+	return &searchUseCase{
+		searchQuery: searchQuery,
+		registry:    registry,
+	}
+}
+
+// Search returns one page of matching verses and the total number of
+// matches, counted only when the page does not tell
+func (q *searchUseCase) Search(ctx context.Context, filter *model.Filter) ([]*model.Result, int, error) {
+	ctxt := "SearchUseCase-Search"
+	if err := q.prepareFilter(filter); err != nil {
+		return nil, 0, err
+	}
+	filter.Page = max(filter.Page, 1)
+	response, err := q.searchQuery.FindVerses(ctx, filter)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindVerses")
+		return nil, 0, err
+	}
+	total, err := helper.Total(filter.Page, filter.PerPage, len(response), func() (int, error) {
+		return q.searchQuery.CountVerses(ctx, filter)
+	})
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCountVerses")
+		return nil, 0, err
+	}
+	return response, total, nil
+}
+
+// SearchAfter returns the page of matching verses following cursor, from
+// the first match when empty, and the cursor of the next page, empty
+// on the last one. Keyset pages stay cheap however deep they go.
+func (q *searchUseCase) SearchAfter(ctx context.Context, filter *model.Filter, cursor string) ([]*model.Result, string, error) {
+	ctxt := "SearchUseCase-SearchAfter"
+	if err := q.prepareFilter(filter); err != nil {
+		return nil, "", err
+	}
+	filter.Keyset = true
+	if cursor != "" {
+		var after model.Cursor
+		if err := helper.DecodeCursor(cursor, &after.Rank, &after.Ordinal, &after.Translation); err != nil {
+			return nil, "", err
+		}
+		filter.After = &after
+	}
+	response, err := q.searchQuery.FindVerses(ctx, filter)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindVerses")
+		return nil, "", err
+	}
+	if len(response) <= filter.PerPage {
+		return response, "", nil
+	}
+	response = response[:filter.PerPage]
+	last := response[len(response)-1]
+	next, err := helper.EncodeCursor(last.Rank, last.Ordinal, last.Translation)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrEncodeCursor")
+		return nil, "", err
+	}
+	return response, next, nil
+}
+
+func (q *searchUseCase) prepareFilter(filter *model.Filter) error {
+	if filter.Query = strings.TrimSpace(filter.Query); filter.Query == "" {
+		return model.ErrQueryRequired
+	}
+	filter.Testament = strings.ToUpper(filter.Testament)
+	if filter.Testament != "" && filter.Testament != canon.TestamentOld && filter.Testament != canon.TestamentNew {
+		return model.ErrInvalidTestament
+	}
+	switch filter.Sort {
+	case "":
+		filter.Sort = model.SortRelevance
+	case model.SortRelevance, model.SortCanonical:
+	default:
+		return model.ErrInvalidSort
+	}
+	if filter.PerPage == 0 {
+		filter.PerPage = model.DefaultPerPage
+	}
+	for i, translation := range filter.Translations {
+		filter.Translations[i] = strings.ToLower(translation)
+	}
+	return nil
+}
+
+func (q *searchUseCase) ResolveBook(name string) (int, error) {
+	book, ok := q.registry.ResolveBook(name)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", model.ErrUnknownBook, name)
+	}
+	return book.Order, nil
+}
@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/roysitumorang/bible/modules/search/model"
+)
+
+type (
+	SearchUseCase interface {
+		Search(ctx context.Context, filter *model.Filter) ([]*model.Result, int, error)
+		SearchAfter(ctx context.Context, filter *model.Filter, cursor string) ([]*model.Result, string, error)
+		ResolveBook(name string) (int, error)
+	}
+)
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"embed"
+	"errors"
+	"time"
+
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+)
+
+type (
+	// VerseOfTheDay is the reference picked for a language on a date;
+	// Reference is in OSIS form and numbered in Versification
+	VerseOfTheDay struct {
+		Language      string
+		Date          time.Time
+		Reference     string
+		Versification string
+		CreatedAt     time.Time
+	}
+
+	// List is a curated list of references for one language, as found in
+	// the data files
+	List struct {
+		Language      string   `json:"language"`
+		Versification string   `json:"versification"`
+		References    []string `json:"references"`
+	}
+
+	Config struct {
+		// cron spec of the daily pick, evaluated in helper.LoadTimeZone
+		Schedule string
+		// a reference is not picked again within this many days
+		Window int
+	}
+
+	Response struct {
+		Date      string                  `json:"date"`
+		Language  string                  `json:"language"`
+		Reference string                  `json:"reference"`
+		Passage   *scriptureModel.Passage `json:"passage"`
+	}
+)
+
+const (
+	DateLayout = "2006-01-02"
+
+	DefaultSchedule = "1 0 * * *"
+	DefaultWindow   = 30
+)
+
+var (
+	//go:embed data/*.json
+	Data embed.FS
+
+	ErrNotFound        = errors.New("verse of the day not found")
+	ErrUnknownLanguage = errors.New("no verse of the day list for language")
+	ErrInvalidDate     = errors.New("date: expected YYYY-MM-DD")
+	ErrFutureDate      = errors.New("date: verse of the day not picked yet")
+)
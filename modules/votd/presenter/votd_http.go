@@ -0,0 +1,53 @@
+package presenter
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/roysitumorang/bible/helper"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+	"github.com/roysitumorang/bible/modules/votd/model"
+	"github.com/roysitumorang/bible/modules/votd/usecase"
+	"go.uber.org/zap"
+)
+
+type (
+	VotdHTTPHandler struct {
+		votdUseCase usecase.VotdUseCase
+	}
+)
+
+func NewVotdHTTPHandler(votdUseCase usecase.VotdUseCase) *VotdHTTPHandler {
+	return &VotdHTTPHandler{
+		votdUseCase: votdUseCase,
+	}
+}
+
+func (q *VotdHTTPHandler) Mount(r fiber.Router) {
+	r.Get("", q.FindVerseOfTheDay)
+}
+
+// FindVerseOfTheDay handles GET /v1/votd?lang=id&date=2024-12-25&translation=tb
+func (q *VotdHTTPHandler) FindVerseOfTheDay(c *fiber.Ctx) error {
+	ctx := helper.GetContext(c.UserContext(), c)
+	ctxt := "VotdHTTPHandler-FindVerseOfTheDay"
+	response, err := q.votdUseCase.FindVerseOfTheDay(ctx, c.Query("lang"), c.Query("date"), c.Query("translation"))
+	if err != nil {
+		helper.Log(ctx, zap.ErrorLevel, err.Error(), ctxt, "ErrFindVerseOfTheDay")
+		return helper.NewResponse(statusCode(err), err.Error(), nil).WriteResponse(c)
+	}
+	return helper.NewResponse(fiber.StatusOK, "", response).WriteResponse(c)
+}
+
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, model.ErrNotFound),
+		errors.Is(err, model.ErrUnknownLanguage),
+		errors.Is(err, scriptureModel.ErrTranslationNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, model.ErrInvalidDate),
+		errors.Is(err, model.ErrFutureDate):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
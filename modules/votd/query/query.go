@@ -0,0 +1,16 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/roysitumorang/bible/modules/votd/model"
+)
+
+type (
+	VotdQuery interface {
+		FindVerseOfTheDay(ctx context.Context, language string, date time.Time) (*model.VerseOfTheDay, error)
+		FindRecentReferences(ctx context.Context, language string, since time.Time) (map[string]time.Time, error)
+		CreateVerseOfTheDay(ctx context.Context, votd *model.VerseOfTheDay) (*model.VerseOfTheDay, error)
+	}
+)
@@ -0,0 +1,151 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/roysitumorang/bible/helper"
+	"github.com/roysitumorang/bible/modules/votd/model"
+	"go.uber.org/zap"
+)
+
+type (
+	votdQuery struct {
+		dbRead  *pgxpool.Pool
+		dbWrite *pgxpool.Pool
+	}
+)
+
+func NewVotdQuery(dbRead, dbWrite *pgxpool.Pool) VotdQuery {
+	return &votdQuery{
+		dbRead:  dbRead,
+		dbWrite: dbWrite,
+	}
+}
+
+func (q *votdQuery) FindVerseOfTheDay(ctx context.Context, language string, date time.Time) (*model.VerseOfTheDay, error) {
+	ctxt := "VotdQuery-FindVerseOfTheDay"
+	var response model.VerseOfTheDay
+	err := q.dbRead.QueryRow(
+		ctx,
+		`SELECT "language", "date", "reference", "versification", "created_at"
+		FROM verses_of_the_day
+		WHERE "language" = $1
+			AND "date" = $2`,
+		language,
+		date,
+	).Scan(
+		&response.Language,
+		&response.Date,
+		&response.Reference,
+		&response.Versification,
+		&response.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, model.ErrNotFound
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return nil, err
+	}
+	return &response, nil
+}
+
+// FindRecentReferences returns the references picked for language since
+// the given date, with the date each was last picked
+func (q *votdQuery) FindRecentReferences(ctx context.Context, language string, since time.Time) (map[string]time.Time, error) {
+	ctxt := "VotdQuery-FindRecentReferences"
+	rows, err := q.dbWrite.Query(
+		ctx,
+		`SELECT "reference", MAX("date")
+		FROM verses_of_the_day
+		WHERE "language" = $1
+			AND "date" >= $2
+		GROUP BY "reference"`,
+		language,
+		since,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrQuery")
+		return nil, err
+	}
+	defer rows.Close()
+	response := map[string]time.Time{}
+	for rows.Next() {
+		var (
+			reference string
+			date      time.Time
+		)
+		if err := rows.Scan(&reference, &date); err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+			return nil, err
+		}
+		response[reference] = date
+	}
+	return response, nil
+}
+
+// CreateVerseOfTheDay stores the pick unless another instance already
+// stored one for the same language and date, in which case that one wins
+func (q *votdQuery) CreateVerseOfTheDay(ctx context.Context, votd *model.VerseOfTheDay) (*model.VerseOfTheDay, error) {
+	ctxt := "VotdQuery-CreateVerseOfTheDay"
+	var response model.VerseOfTheDay
+	err := q.dbWrite.QueryRow(
+		ctx,
+		`WITH inserted AS (
+			INSERT INTO verses_of_the_day ("language", "date", "reference", "versification")
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT ("language", "date") DO NOTHING
+			RETURNING "language", "date", "reference", "versification", "created_at"
+		)
+		SELECT "language", "date", "reference", "versification", "created_at"
+		FROM inserted
+		UNION ALL
+		SELECT "language", "date", "reference", "versification", "created_at"
+		FROM verses_of_the_day
+		WHERE "language" = $1
+			AND "date" = $2
+		LIMIT 1`,
+		votd.Language,
+		votd.Date,
+		votd.Reference,
+		votd.Versification,
+	).Scan(
+		&response.Language,
+		&response.Date,
+		&response.Reference,
+		&response.Versification,
+		&response.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// the other instance committed after the snapshot of the statement
+		// above was taken: its row conflicted but was not visible to the
+		// SELECT, a new statement sees it
+		err = q.dbWrite.QueryRow(
+			ctx,
+			`SELECT "language", "date", "reference", "versification", "created_at"
+			FROM verses_of_the_day
+			WHERE "language" = $1
+				AND "date" = $2`,
+			votd.Language,
+			votd.Date,
+		).Scan(
+			&response.Language,
+			&response.Date,
+			&response.Reference,
+			&response.Versification,
+			&response.CreatedAt,
+		)
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrScan")
+		return nil, err
+	}
+	return &response, nil
+}
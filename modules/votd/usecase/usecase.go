@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/roysitumorang/bible/modules/votd/model"
+)
+
+type (
+	VotdUseCase interface {
+		PickAll(ctx context.Context, now time.Time) error
+		Pick(ctx context.Context, language string, date time.Time) (*model.VerseOfTheDay, error)
+		FindVerseOfTheDay(ctx context.Context, language, date, translationCode string) (*model.Response, error)
+	}
+)
@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/roysitumorang/bible/canon"
+	"github.com/roysitumorang/bible/helper"
+	scriptureModel "github.com/roysitumorang/bible/modules/scripture/model"
+	scriptureUseCase "github.com/roysitumorang/bible/modules/scripture/usecase"
+	"github.com/roysitumorang/bible/modules/votd/model"
+	"github.com/roysitumorang/bible/modules/votd/query"
+	"github.com/roysitumorang/bible/reference"
+	"github.com/roysitumorang/bible/versification"
+	"go.uber.org/zap"
+)
+
+type (
+	votdUseCase struct {
+		votdQuery        query.VotdQuery
+		scriptureUseCase scriptureUseCase.ScriptureUseCase
+		config           *model.Config
+		lists            map[string]*model.List
+	}
+)
+
+func NewVotdUseCase(
+	votdQuery query.VotdQuery,
+	scriptureUseCase scriptureUseCase.ScriptureUseCase,
+	registry *canon.Registry,
+	config *model.Config,
+) (VotdUseCase, error) {
+	lists, err := loadLists(model.Data, "data", reference.NewParser(registry))
+	if err != nil {
+		return nil, err
+	}
+	return &votdUseCase{
+		votdQuery:        votdQuery,
+		scriptureUseCase: scriptureUseCase,
+		config:           config,
+		lists:            lists,
+	}, nil
+}
+
+// LoadConfig reads VOTD_SCHEDULE and VOTD_WINDOW_DAYS, both optional
+func LoadConfig() (*model.Config, error) {
+	response := model.Config{
+		Schedule: model.DefaultSchedule,
+		Window:   model.DefaultWindow,
+	}
+	if schedule, ok := os.LookupEnv("VOTD_SCHEDULE"); ok && schedule != "" {
+		response.Schedule = schedule
+	}
+	if envWindow, ok := os.LookupEnv("VOTD_WINDOW_DAYS"); ok && envWindow != "" {
+		window, err := strconv.Atoi(envWindow)
+		if err != nil || window < 0 {
+			return nil, errors.New("env VOTD_WINDOW_DAYS requires a non-negative integer")
+		}
+		response.Window = window
+	}
+	return &response, nil
+}
+
+// PickAll makes sure every language has its verse for the day now falls
+// on in helper.LoadTimeZone
+func (q *votdUseCase) PickAll(ctx context.Context, now time.Time) error {
+	ctxt := "VotdUseCase-PickAll"
+	date := helper.Day(now)
+	languages := make([]string, 0, len(q.lists))
+	for language := range q.lists {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	for _, language := range languages {
+		votd, err := q.Pick(ctx, language, date)
+		if err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrPick")
+			return err
+		}
+		helper.Log(ctx, zap.InfoLevel, fmt.Sprintf("votd: %s %s %s", votd.Language, votd.Date.Format(model.DateLayout), votd.Reference), ctxt, "")
+	}
+	return nil
+}
+
+// Pick returns the verse of language for date, picking one when there is
+// none yet: references picked within the configured window are skipped,
+// the least recently picked ones are used once the list runs out
+func (q *votdUseCase) Pick(ctx context.Context, language string, date time.Time) (*model.VerseOfTheDay, error) {
+	ctxt := "VotdUseCase-Pick"
+	list, ok := q.lists[language]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", model.ErrUnknownLanguage, language)
+	}
+	response, err := q.votdQuery.FindVerseOfTheDay(ctx, language, date)
+	if err == nil {
+		return response, nil
+	}
+	if !errors.Is(err, model.ErrNotFound) {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindVerseOfTheDay")
+		return nil, err
+	}
+	recent, err := q.votdQuery.FindRecentReferences(ctx, language, date.AddDate(0, 0, -q.config.Window))
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindRecentReferences")
+		return nil, err
+	}
+	var candidates []string
+	for _, reference := range list.References {
+		if _, ok := recent[reference]; !ok {
+			candidates = append(candidates, reference)
+		}
+	}
+	if len(candidates) == 0 {
+		var oldest time.Time
+		for _, reference := range list.References {
+			switch last := recent[reference]; {
+			case len(candidates) == 0 || last.Before(oldest):
+				candidates, oldest = []string{reference}, last
+			case last.Equal(oldest):
+				candidates = append(candidates, reference)
+			}
+		}
+	}
+	// the same date always lands on the same candidate, so instances
+	// racing on the insert agree anyway
+	hash := fnv.New32a()
+	_, _ = hash.Write(helper.String2ByteSlice(language + date.Format(model.DateLayout)))
+	if response, err = q.votdQuery.CreateVerseOfTheDay(
+		ctx,
+		&model.VerseOfTheDay{
+			Language:      language,
+			Date:          date,
+			Reference:     candidates[hash.Sum32()%uint32(len(candidates))],
+			Versification: list.Versification,
+		},
+	); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrCreateVerseOfTheDay")
+		return nil, err
+	}
+	return response, nil
+}
+
+// FindVerseOfTheDay returns the verse of language on date (today when
+// empty) with its text in translationCode, by default the first
+// translation in that language
+func (q *votdUseCase) FindVerseOfTheDay(ctx context.Context, language, date, translationCode string) (*model.Response, error) {
+	ctxt := "VotdUseCase-FindVerseOfTheDay"
+	if language = strings.ToLower(language); language == "" {
+		language = canon.DefaultLanguage
+	}
+	if _, ok := q.lists[language]; !ok {
+		return nil, fmt.Errorf("%w: %s", model.ErrUnknownLanguage, language)
+	}
+	today := helper.Day(time.Now())
+	target := today
+	if date != "" {
+		var err error
+		if target, err = time.Parse(model.DateLayout, date); err != nil {
+			return nil, model.ErrInvalidDate
+		}
+	}
+	if target.After(today) {
+		return nil, model.ErrFutureDate
+	}
+	votd, err := q.votdQuery.FindVerseOfTheDay(ctx, language, target)
+	if errors.Is(err, model.ErrNotFound) && target.Equal(today) {
+		// the scheduled job has not run yet
+		votd, err = q.Pick(ctx, language, target)
+	}
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindVerseOfTheDay")
+		return nil, err
+	}
+	response := model.Response{
+		Date:      votd.Date.Format(model.DateLayout),
+		Language:  votd.Language,
+		Reference: votd.Reference,
+	}
+	if translationCode == "" {
+		translations, err := q.scriptureUseCase.FindTranslations(ctx)
+		if err != nil {
+			helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindTranslations")
+			return nil, err
+		}
+		for _, translation := range translations {
+			if strings.EqualFold(translation.Language, language) {
+				translationCode = translation.Code
+				break
+			}
+		}
+		if translationCode == "" {
+			return &response, nil
+		}
+	}
+	if response.Passage, err = q.scriptureUseCase.FindPassage(ctx, translationCode, votd.Reference, votd.Versification); err != nil && !errors.Is(err, scriptureModel.ErrVerseNotFound) {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrFindPassage")
+		return nil, err
+	}
+	return &response, nil
+}
+
+// loadLists reads every *.json list in dir, normalizing the references
+// to OSIS
+func loadLists(fsys fs.FS, dir string, parser *reference.Parser) (map[string]*model.List, error) {
+	paths, err := fs.Glob(fsys, dir+"/*.json")
+	if err != nil {
+		return nil, err
+	}
+	response := make(map[string]*model.List, len(paths))
+	for _, filepath := range paths {
+		content, err := fs.ReadFile(fsys, filepath)
+		if err != nil {
+			return nil, err
+		}
+		var list model.List
+		if err := json.Unmarshal(content, &list); err != nil {
+			return nil, fmt.Errorf("votd: %s: %w", filepath, err)
+		}
+		if list.Language = strings.ToLower(list.Language); list.Language == "" || len(list.References) == 0 {
+			return nil, fmt.Errorf("votd: %s: language and references are required", filepath)
+		}
+		if list.Versification == "" {
+			list.Versification = versification.Standard
+		}
+		for i, item := range list.References {
+			ranges, err := parser.Parse(item)
+			if err != nil {
+				return nil, fmt.Errorf("votd: %s: %w", filepath, err)
+			}
+			list.References[i] = reference.Format(ranges)
+		}
+		response[list.Language] = &list
+	}
+	return response, nil
+}
@@ -0,0 +1,76 @@
+package reference
+
+import (
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+func lex(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	// byte offsets of each rune, plus the end of input
+	offsets := make([]int, len(runes)+1)
+	var n int
+	for i, r := range runes {
+		offsets[i] = n
+		n += utf8.RuneLen(r)
+	}
+	offsets[len(runes)] = n
+	isDigit := func(i int) bool {
+		return i < len(runes) && unicode.IsDigit(runes[i])
+	}
+	isLetter := func(i int) bool {
+		return i < len(runes) && unicode.IsLetter(runes[i])
+	}
+	for i := 0; i < len(runes); {
+		r, offset := runes[i], offsets[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r):
+			j := i
+			for isDigit(j) {
+				j++
+			}
+			text := string(runes[i:j])
+			if j-i > maxDigits {
+				return nil, &ParseError{Input: input, Offset: offset, Message: "number " + strconv.Quote(text) + " is too large"}
+			}
+			value, _ := strconv.Atoi(text)
+			tokens = append(tokens, token{kind: tokenNumber, text: text, value: value, offset: offset})
+			i = j
+		case unicode.IsLetter(r):
+			// words may contain inner hyphens ("Hakim-hakim") and end
+			// with an abbreviation dot ("Gen.")
+			j := i
+			for isLetter(j) || j < len(runes) && runes[j] == '-' && isLetter(j+1) && j > i {
+				j++
+			}
+			text := string(runes[i:j])
+			if j < len(runes) && runes[j] == '.' && !isDigit(j+1) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: text, offset: offset})
+			i = j
+		case r == ':' || r == '.' && isDigit(i+1) && i > 0 && isDigit(i-1):
+			tokens = append(tokens, token{kind: tokenColon, text: string(r), offset: offset})
+			i++
+		case r == '.' && i > 0 && isLetter(i-1):
+			// OSIS style "John.3.16"
+			i++
+		case r == '-' || r == '–' || r == '—':
+			tokens = append(tokens, token{kind: tokenDash, text: string(r), offset: offset})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: string(r), offset: offset})
+			i++
+		case r == ';':
+			tokens = append(tokens, token{kind: tokenSemicolon, text: string(r), offset: offset})
+			i++
+		default:
+			return nil, &ParseError{Input: input, Offset: offset, Message: "unexpected character " + strconv.QuoteRune(r)}
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, offset: len(input)}), nil
+}
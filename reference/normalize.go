@@ -0,0 +1,25 @@
+package reference
+
+import (
+	"strings"
+)
+
+// NormalizeBookName lower-cases a book name and drops spaces, dots and
+// hyphens; a leading Roman numeral is turned into a digit, so
+// "I Yohanes", "1 Yoh." and "1yoh" all normalize alike
+func NormalizeBookName(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == ' ' || r == '.' || r == '\t'
+	})
+	if len(fields) > 1 {
+		switch fields[0] {
+		case "i":
+			fields[0] = "1"
+		case "ii":
+			fields[0] = "2"
+		case "iii":
+			fields[0] = "3"
+		}
+	}
+	return strings.ReplaceAll(strings.Join(fields, ""), "-", "")
+}
@@ -0,0 +1,344 @@
+package reference
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Book is the minimum a parser needs to know about a resolved book name
+	Book struct {
+		Code     string
+		Order    int
+		Chapters int
+	}
+
+	// BookResolver maps a human-typed book name or abbreviation to a book
+	BookResolver interface {
+		ResolveBook(name string) (*Book, bool)
+	}
+
+	// Point addresses a verse; Verse 0 means the whole chapter
+	Point struct {
+		Book    string `json:"book"`
+		Chapter int    `json:"chapter"`
+		Verse   int    `json:"verse"`
+	}
+
+	Range struct {
+		Start Point `json:"start"`
+		End   Point `json:"end"`
+	}
+
+	ParseError struct {
+		Input   string
+		Offset  int
+		Message string
+	}
+
+	Parser struct {
+		resolver BookResolver
+	}
+)
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("reference: %s at offset %d in %q", e.Message, e.Offset, e.Input)
+}
+
+func (p Point) String() string {
+	if p.Verse == 0 {
+		return fmt.Sprintf("%s.%d", p.Book, p.Chapter)
+	}
+	return fmt.Sprintf("%s.%d.%d", p.Book, p.Chapter, p.Verse)
+}
+
+// OSIS formats the range as an OSIS reference, e.g. "John.3.16-John.3.18"
+func (r Range) OSIS() string {
+	if r.Start == r.End {
+		return r.Start.String()
+	}
+	return r.Start.String() + "-" + r.End.String()
+}
+
+func NewParser(resolver BookResolver) *Parser {
+	return &Parser{
+		resolver: resolver,
+	}
+}
+
+// Parse parses references such as "John 3:16-18", "Rom 8; 12:1-2",
+// "1 Cor 13", "Gen 1:1-2:3" or "John.3.16" into verse ranges. Ranges
+// are returned in input order; a book given without chapters spans
+// the whole book.
+func (p *Parser) Parse(input string) ([]Range, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	state := &parser{
+		resolver: p.resolver,
+		input:    input,
+		tokens:   tokens,
+	}
+	return state.parse()
+}
+
+// Format joins ranges back into a single OSIS reference list
+func Format(ranges []Range) string {
+	refs := make([]string, len(ranges))
+	for i, r := range ranges {
+		refs[i] = r.OSIS()
+	}
+	return strings.Join(refs, ",")
+}
+
+type (
+	parser struct {
+		resolver BookResolver
+		input    string
+		tokens   []token
+		pos      int
+	}
+)
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(n int) token {
+	if i := p.pos + n; i < len(p.tokens) {
+		return p.tokens[i]
+	}
+	return p.tokens[len(p.tokens)-1]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorf(offset int, format string, args ...interface{}) *ParseError {
+	return &ParseError{
+		Input:   p.input,
+		Offset:  offset,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// isBookStart reports whether a book name begins at the current token:
+// a word, or a numeral directly followed by a word ("1 Cor", "1Yoh")
+func (p *parser) isBookStart() bool {
+	switch p.peek().kind {
+	case tokenWord:
+		return true
+	case tokenNumber:
+		return p.peekAt(1).kind == tokenWord
+	}
+	return false
+}
+
+func (p *parser) parseBook() (*Book, error) {
+	start := p.peek()
+	parts := make([]string, 0, 3)
+	if start.kind == tokenNumber {
+		parts = append(parts, p.next().text)
+	}
+	for p.peek().kind == tokenWord {
+		parts = append(parts, p.next().text)
+	}
+	name := strings.Join(parts, " ")
+	book, ok := p.resolver.ResolveBook(name)
+	if !ok {
+		return nil, p.errorf(start.offset, "unknown book %q", name)
+	}
+	return book, nil
+}
+
+func (p *parser) expectNumber(what string) (token, error) {
+	t := p.peek()
+	if t.kind != tokenNumber {
+		return t, p.errorf(t.offset, "%s expected, found %s", what, t.describe())
+	}
+	if t.value < 1 {
+		return t, p.errorf(t.offset, "%s must be positive", what)
+	}
+	return p.next(), nil
+}
+
+func (p *parser) parse() ([]Range, error) {
+	if p.peek().kind == tokenEOF {
+		return nil, p.errorf(0, "empty reference")
+	}
+	var (
+		ranges []Range
+		book   *Book
+	)
+	for {
+		segment, last, err := p.parseSegment(book)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, segment...)
+		book = last
+		t := p.next()
+		switch t.kind {
+		case tokenEOF:
+			return ranges, nil
+		case tokenSemicolon:
+			if p.peek().kind == tokenEOF {
+				return ranges, nil
+			}
+		default:
+			return nil, p.errorf(t.offset, "unexpected %s", t.describe())
+		}
+	}
+}
+
+// parseSegment parses everything up to the next semicolon. Bare
+// numbers inherit the book of the previous segment, so "Rom 8; 12:1-2"
+// yields two ranges in Romans.
+func (p *parser) parseSegment(book *Book) ([]Range, *Book, error) {
+	if p.isBookStart() {
+		var err error
+		if book, err = p.parseBook(); err != nil {
+			return nil, nil, err
+		}
+		if t := p.peek(); t.kind == tokenEOF || t.kind == tokenSemicolon {
+			return []Range{{
+				Start: Point{Book: book.Code, Chapter: 1},
+				End:   Point{Book: book.Code, Chapter: book.Chapters},
+			}}, book, nil
+		}
+	} else if book == nil {
+		t := p.peek()
+		return nil, nil, p.errorf(t.offset, "book name expected, found %s", t.describe())
+	}
+	var (
+		ranges []Range
+		// chapter of the previous item when it ended at a verse, so
+		// "3:16, 18" reads 18 as a verse rather than a chapter
+		verseChapter int
+	)
+	for {
+		if p.isBookStart() {
+			var err error
+			if book, err = p.parseBook(); err != nil {
+				return nil, nil, err
+			}
+			verseChapter = 0
+		}
+		r, endBook, err := p.parseItem(book, verseChapter)
+		if err != nil {
+			return nil, nil, err
+		}
+		ranges = append(ranges, r)
+		book = endBook
+		verseChapter = 0
+		if r.End.Verse > 0 {
+			verseChapter = r.End.Chapter
+		}
+		if p.peek().kind != tokenComma {
+			return ranges, book, nil
+		}
+		p.next()
+	}
+}
+
+func (p *parser) parseItem(book *Book, verseChapter int) (Range, *Book, error) {
+	first := p.peek()
+	start, err := p.parsePoint(book, verseChapter)
+	if err != nil {
+		return Range{}, nil, err
+	}
+	end, endBook := start, book
+	if p.peek().kind == tokenDash {
+		p.next()
+		if p.isBookStart() {
+			if endBook, err = p.parseBook(); err != nil {
+				return Range{}, nil, err
+			}
+			if end, err = p.parsePoint(endBook, 0); err != nil {
+				return Range{}, nil, err
+			}
+		} else {
+			chapter := 0
+			if start.Verse > 0 {
+				chapter = start.Chapter
+			}
+			if end, err = p.parsePoint(book, chapter); err != nil {
+				return Range{}, nil, err
+			}
+		}
+	}
+	if endBook.Order < book.Order ||
+		endBook.Order == book.Order && (end.Chapter < start.Chapter ||
+			end.Chapter == start.Chapter && end.Verse > 0 && end.Verse < start.Verse) {
+		return Range{}, nil, p.errorf(first.offset, "range end precedes its start")
+	}
+	return Range{Start: start, End: end}, endBook, nil
+}
+
+// parsePoint parses "chapter", "chapter:verse" or, when verseChapter is
+// set or the book has a single chapter, a bare verse number
+func (p *parser) parsePoint(book *Book, verseChapter int) (Point, error) {
+	point := Point{Book: book.Code}
+	t, err := p.expectNumber("chapter or verse")
+	if err != nil {
+		return point, err
+	}
+	switch {
+	case p.peek().kind == tokenColon:
+		p.next()
+		point.Chapter = t.value
+		v, err := p.expectNumber("verse")
+		if err != nil {
+			return point, err
+		}
+		point.Verse = v.value
+	case verseChapter > 0:
+		point.Chapter, point.Verse = verseChapter, t.value
+	case book.Chapters == 1:
+		point.Chapter, point.Verse = 1, t.value
+	default:
+		point.Chapter = t.value
+	}
+	if point.Chapter > book.Chapters {
+		return point, p.errorf(t.offset, "%s has only %d chapters", book.Code, book.Chapters)
+	}
+	return point, nil
+}
+
+type (
+	tokenKind int
+
+	token struct {
+		kind   tokenKind
+		text   string
+		value  int
+		offset int
+	}
+)
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenNumber
+	tokenColon
+	tokenDash
+	tokenComma
+	tokenSemicolon
+
+	// longest accepted chapter or verse number
+	maxDigits = 3
+)
+
+func (t token) describe() string {
+	if t.kind == tokenEOF {
+		return "end of input"
+	}
+	return strconv.Quote(t.text)
+}
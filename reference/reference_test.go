@@ -0,0 +1,104 @@
+package reference_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/roysitumorang/bible/canon"
+	"github.com/roysitumorang/bible/reference"
+)
+
+func newParser(t *testing.T) *reference.Parser {
+	t.Helper()
+	registry, err := canon.Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reference.NewParser(registry)
+}
+
+func TestParse(t *testing.T) {
+	parser := newParser(t)
+	for _, tc := range []struct {
+		input, want string
+	}{
+		{"John 3:16-18", "John.3.16-John.3.18"},
+		{"Rom 8; 12:1-2", "Rom.8,Rom.12.1-Rom.12.2"},
+		{"1 Cor 13", "1Cor.13"},
+		{"Gen 1:1-2:3", "Gen.1.1-Gen.2.3"},
+		{"Yoh 3:16", "John.3.16"},
+		{"I Yohanes", "1John.1-1John.5"},
+		{"1Yoh", "1John.1-1John.5"},
+		// OSIS references, as stored by crossref and votd
+		{"Gen.1.1", "Gen.1.1"},
+		{"John.1.1-John.1.3", "John.1.1-John.1.3"},
+		// comma lists
+		{"John 3:16, 18", "John.3.16,John.3.18"},
+		{"John 3:16,18-20", "John.3.16,John.3.18-John.3.20"},
+		{"John 3:16, 4:1", "John.3.16,John.4.1"},
+		{"Rom 8, 9", "Rom.8,Rom.9"},
+		// cross-book ranges
+		{"Gen 50:26-Exod 1:5", "Gen.50.26-Exod.1.5"},
+		{"Mal 4-Matt 1", "Mal.4-Matt.1"},
+		// single-chapter books take a bare number as the verse
+		{"Jude 5", "Jude.1.5"},
+		{"Jude 1:5", "Jude.1.5"},
+		{"Jude 3-5", "Jude.1.3-Jude.1.5"},
+		// en and em dashes
+		{"John 3:16–18", "John.3.16-John.3.18"},
+		{"John 3:16—18", "John.3.16-John.3.18"},
+		{"Gen 1:1–2:3", "Gen.1.1-Gen.2.3"},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			ranges, err := parser.Parse(tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := reference.Format(ranges); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	parser := newParser(t)
+	for _, tc := range []struct {
+		input  string
+		offset int
+	}{
+		{"", 0},
+		{"Foo 1:1", 0},
+		{"John 3:", 7},
+		{"John 3:16-", 10},
+		{"John 3;;", 7},
+		{"John 3:16 @", 10},
+		{"John 3:16,", 10},
+		{",John", 0},
+		{"Gen 1:1-Foo 2", 8},
+		{"Gen 1-Exod 41", 11},
+		{"Jude 2:1", 5},
+		// reversed ranges
+		{"John 3:18-16", 5},
+		{"John 5-3", 5},
+		{"Exod 1-Gen 2", 5},
+		{"Rom 8; 9:3-2", 7},
+		// chapters past the end of the book
+		{"John 22", 5},
+		{"John 3:16, 22:1", 11},
+		// offsets count bytes, an en or em dash is three
+		{"John 3:16–", 12},
+		{"John 3:16—x", 12},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := parser.Parse(tc.input)
+			var parseError *reference.ParseError
+			if !errors.As(err, &parseError) {
+				t.Fatalf("got %v, want a *ParseError", err)
+			}
+			if parseError.Offset != tc.offset {
+				t.Errorf("got offset %d, want %d (%s)", parseError.Offset, tc.offset, parseError.Message)
+			}
+		})
+	}
+}
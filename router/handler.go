@@ -3,21 +3,69 @@ package router
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/roysitumorang/bible/canon"
 	"github.com/roysitumorang/bible/config"
 	"github.com/roysitumorang/bible/helper"
 	"github.com/roysitumorang/bible/migration"
+	annotationQuery "github.com/roysitumorang/bible/modules/annotation/query"
+	annotationUseCase "github.com/roysitumorang/bible/modules/annotation/usecase"
+	audioQuery "github.com/roysitumorang/bible/modules/audio/query"
+	audioUseCase "github.com/roysitumorang/bible/modules/audio/usecase"
+	authQuery "github.com/roysitumorang/bible/modules/auth/query"
+	authUseCase "github.com/roysitumorang/bible/modules/auth/usecase"
+	comparisonUseCase "github.com/roysitumorang/bible/modules/comparison/usecase"
+	concordanceModel "github.com/roysitumorang/bible/modules/concordance/model"
+	concordanceQuery "github.com/roysitumorang/bible/modules/concordance/query"
+	concordanceUseCase "github.com/roysitumorang/bible/modules/concordance/usecase"
+	crossRefQuery "github.com/roysitumorang/bible/modules/crossref/query"
+	crossRefUseCase "github.com/roysitumorang/bible/modules/crossref/usecase"
+	exporterUseCase "github.com/roysitumorang/bible/modules/exporter/usecase"
+	importerQuery "github.com/roysitumorang/bible/modules/importer/query"
+	importerUseCase "github.com/roysitumorang/bible/modules/importer/usecase"
+	lexiconQuery "github.com/roysitumorang/bible/modules/lexicon/query"
+	lexiconUseCase "github.com/roysitumorang/bible/modules/lexicon/usecase"
+	readingPlanQuery "github.com/roysitumorang/bible/modules/readingplan/query"
+	readingPlanUseCase "github.com/roysitumorang/bible/modules/readingplan/usecase"
+	scriptureQuery "github.com/roysitumorang/bible/modules/scripture/query"
+	scriptureUseCase "github.com/roysitumorang/bible/modules/scripture/usecase"
+	searchQuery "github.com/roysitumorang/bible/modules/search/query"
+	searchUseCase "github.com/roysitumorang/bible/modules/search/usecase"
+	votdModel "github.com/roysitumorang/bible/modules/votd/model"
+	votdQuery "github.com/roysitumorang/bible/modules/votd/query"
+	votdUseCase "github.com/roysitumorang/bible/modules/votd/usecase"
+	"github.com/roysitumorang/bible/versification"
 	"go.uber.org/zap"
 )
 
 type (
 	Service struct {
-		Migration *migration.Migration
+		DbRead             *pgxpool.Pool
+		DbWrite            *pgxpool.Pool
+		Registry           *canon.Registry
+		Versifications     *versification.Registry
+		Migration          *migration.Migration
+		ScriptureUseCase   scriptureUseCase.ScriptureUseCase
+		ImporterUseCase    importerUseCase.ImporterUseCase
+		ExporterUseCase    exporterUseCase.ExporterUseCase
+		SearchUseCase      searchUseCase.SearchUseCase
+		ComparisonUseCase  comparisonUseCase.ComparisonUseCase
+		VotdUseCase        votdUseCase.VotdUseCase
+		VotdConfig         *votdModel.Config
+		ReadingPlanUseCase readingPlanUseCase.ReadingPlanUseCase
+		AnnotationUseCase  annotationUseCase.AnnotationUseCase
+		AuthUseCase        authUseCase.AuthUseCase
+		LexiconUseCase     lexiconUseCase.LexiconUseCase
+		CrossRefUseCase    crossRefUseCase.CrossRefUseCase
+		AudioUseCase       audioUseCase.AudioUseCase
+		ConcordanceUseCase concordanceUseCase.ConcordanceUseCase
+		ConcordanceConfig  *concordanceModel.Config
 	}
 )
 
 func MakeHandler(ctx context.Context) (*Service, error) {
 	ctxt := "Router-MakeHandler"
-	_, err := config.GetDbReadOnly(ctx)
+	dbRead, err := config.GetDbReadOnly(ctx)
 	if err != nil {
 		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrGetDbReadOnly")
 		return nil, err
@@ -27,13 +75,85 @@ func MakeHandler(ctx context.Context) (*Service, error) {
 		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrGetDbWriteOnly")
 		return nil, err
 	}
-	tx, err := dbWrite.Begin(ctx)
+	registry, err := canon.Default()
 	if err != nil {
-		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrBegin")
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrDefault")
 		return nil, err
 	}
-	migration := migration.NewMigration(tx)
+	versifications, err := versification.Default()
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrDefault")
+		return nil, err
+	}
+	migrationConfig, err := migration.LoadConfig()
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoadConfig")
+		return nil, err
+	}
+	if err := migration.LoadSQL(); err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoadSQL")
+		return nil, err
+	}
+	migration := migration.NewMigration(dbWrite, migrationConfig)
+	scriptureQuery := scriptureQuery.NewScriptureQuery(dbRead)
+	scriptureUseCase := scriptureUseCase.NewScriptureUseCase(scriptureQuery, registry, versifications)
+	importerQuery := importerQuery.NewImporterQuery(dbWrite)
+	importerUseCase := importerUseCase.NewImporterUseCase(importerQuery, registry, versifications)
+	exporterUseCase := exporterUseCase.NewExporterUseCase(scriptureQuery, registry)
+	searchQuery := searchQuery.NewSearchQuery(dbRead)
+	searchUseCase := searchUseCase.NewSearchUseCase(searchQuery, registry)
+	comparisonUseCase := comparisonUseCase.NewComparisonUseCase(scriptureQuery, registry, versifications)
+	votdConfig, err := votdUseCase.LoadConfig()
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoadConfig")
+		return nil, err
+	}
+	votdQuery := votdQuery.NewVotdQuery(dbRead, dbWrite)
+	votdUseCase, err := votdUseCase.NewVotdUseCase(votdQuery, scriptureUseCase, registry, votdConfig)
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrNewVotdUseCase")
+		return nil, err
+	}
+	readingPlanQuery := readingPlanQuery.NewReadingPlanQuery(dbRead, dbWrite)
+	readingPlanUseCase := readingPlanUseCase.NewReadingPlanUseCase(readingPlanQuery, scriptureUseCase, registry)
+	annotationQuery := annotationQuery.NewAnnotationQuery(dbRead, dbWrite)
+	annotationUseCase := annotationUseCase.NewAnnotationUseCase(annotationQuery, registry)
+	authConfig, err := authUseCase.LoadConfig()
+	if err != nil {
+		helper.Capture(ctx, zap.ErrorLevel, err, ctxt, "ErrLoadConfig")
+		return nil, err
+	}
+	authQuery := authQuery.NewAuthQuery(dbRead, dbWrite)
+	authUseCase := authUseCase.NewAuthUseCase(authQuery, authConfig)
+	lexiconQuery := lexiconQuery.NewLexiconQuery(dbRead, dbWrite)
+	lexiconUseCase := lexiconUseCase.NewLexiconUseCase(lexiconQuery)
+	crossRefQuery := crossRefQuery.NewCrossRefQuery(dbRead, dbWrite)
+	crossRefUseCase := crossRefUseCase.NewCrossRefUseCase(crossRefQuery, scriptureUseCase, registry)
+	audioQuery := audioQuery.NewAudioQuery(dbRead, dbWrite)
+	audioUseCase := audioUseCase.NewAudioUseCase(audioQuery, scriptureQuery, registry)
+	concordanceConfig := concordanceUseCase.LoadConfig()
+	concordanceQuery := concordanceQuery.NewConcordanceQuery(dbRead, dbWrite)
+	concordanceUseCase := concordanceUseCase.NewConcordanceUseCase(concordanceQuery, scriptureQuery, registry)
 	return &Service{
-		Migration: migration,
+		DbRead:             dbRead,
+		DbWrite:            dbWrite,
+		Registry:           registry,
+		Versifications:     versifications,
+		Migration:          migration,
+		ScriptureUseCase:   scriptureUseCase,
+		ImporterUseCase:    importerUseCase,
+		ExporterUseCase:    exporterUseCase,
+		SearchUseCase:      searchUseCase,
+		ComparisonUseCase:  comparisonUseCase,
+		VotdUseCase:        votdUseCase,
+		VotdConfig:         votdConfig,
+		ReadingPlanUseCase: readingPlanUseCase,
+		AnnotationUseCase:  annotationUseCase,
+		AuthUseCase:        authUseCase,
+		LexiconUseCase:     lexiconUseCase,
+		CrossRefUseCase:    crossRefUseCase,
+		AudioUseCase:       audioUseCase,
+		ConcordanceUseCase: concordanceUseCase,
+		ConcordanceConfig:  concordanceConfig,
 	}, nil
 }
@@ -23,6 +23,18 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/roysitumorang/bible/config"
 	"github.com/roysitumorang/bible/helper"
+	annotationModel "github.com/roysitumorang/bible/modules/annotation/model"
+	annotationHTTP "github.com/roysitumorang/bible/modules/annotation/presenter"
+	audioHTTP "github.com/roysitumorang/bible/modules/audio/presenter"
+	authHTTP "github.com/roysitumorang/bible/modules/auth/presenter"
+	comparisonHTTP "github.com/roysitumorang/bible/modules/comparison/presenter"
+	concordanceHTTP "github.com/roysitumorang/bible/modules/concordance/presenter"
+	crossRefHTTP "github.com/roysitumorang/bible/modules/crossref/presenter"
+	lexiconHTTP "github.com/roysitumorang/bible/modules/lexicon/presenter"
+	readingPlanHTTP "github.com/roysitumorang/bible/modules/readingplan/presenter"
+	scriptureHTTP "github.com/roysitumorang/bible/modules/scripture/presenter"
+	searchHTTP "github.com/roysitumorang/bible/modules/search/presenter"
+	votdHTTP "github.com/roysitumorang/bible/modules/votd/presenter"
 	"go.uber.org/zap"
 )
 
@@ -72,6 +84,19 @@ func (q *Service) HTTPServerMain(ctx context.Context) error {
 			},
 		).WriteResponse(c)
 	})
+	authHTTP.NewAuthHTTPHandler(q.AuthUseCase).Mount(v1.Group("/tokens"))
+	scriptureHTTP.NewScriptureHTTPHandler(q.ScriptureUseCase).Mount(v1)
+	audioHTTP.NewAudioHTTPHandler(q.AudioUseCase).Mount(v1)
+	concordanceHTTP.NewConcordanceHTTPHandler(q.ConcordanceUseCase).Mount(v1)
+	searchHTTP.NewSearchHTTPHandler(q.SearchUseCase).Mount(v1.Group("/search"))
+	comparisonHTTP.NewComparisonHTTPHandler(q.ComparisonUseCase).Mount(v1.Group("/comparisons"))
+	votdHTTP.NewVotdHTTPHandler(q.VotdUseCase).Mount(v1.Group("/votd"))
+	readingPlanHTTP.NewReadingPlanHTTPHandler(q.ReadingPlanUseCase).Mount(v1.Group("/reading-plans"))
+	annotationHTTP.NewAnnotationHTTPHandler(q.AnnotationUseCase, annotationModel.TypeBookmark).Mount(v1.Group("/bookmarks"))
+	annotationHTTP.NewAnnotationHTTPHandler(q.AnnotationUseCase, annotationModel.TypeHighlight).Mount(v1.Group("/highlights"))
+	annotationHTTP.NewAnnotationHTTPHandler(q.AnnotationUseCase, annotationModel.TypeNote).Mount(v1.Group("/notes"))
+	lexiconHTTP.NewLexiconHTTPHandler(q.LexiconUseCase).Mount(v1.Group("/lexicon"))
+	crossRefHTTP.NewCrossRefHTTPHandler(q.CrossRefUseCase).Mount(v1.Group("/cross-references"))
 	v1.Use(basicauth.New(basicauth.Config{
 		Users: map[string]string{
 			os.Getenv("BASIC_AUTH_USERNAME"): os.Getenv("BASIC_AUTH_PASSWORD"),
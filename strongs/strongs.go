@@ -0,0 +1,68 @@
+package strongs
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/roysitumorang/bible/canon"
+)
+
+const (
+	Hebrew = "H"
+	Greek  = "G"
+)
+
+var (
+	// H/G, optional zero padding, the number and an optional extended
+	// Strong's suffix ("H1254a") that the lexicon does not distinguish
+	pattern = regexp.MustCompile(`^(?i:([HG]))0*([1-9][0-9]{0,4})[a-zA-Z]?$`)
+)
+
+// Normalize turns a tagged Strong's number ("H07225", "strong:G3588",
+// "h1254a") into the form the lexicon is keyed by ("H7225")
+func Normalize(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if i := strings.IndexByte(value, ':'); i >= 0 {
+		value = value[i+1:]
+	}
+	match := pattern.FindStringSubmatch(value)
+	if match == nil {
+		return "", false
+	}
+	return strings.ToUpper(match[1]) + match[2], true
+}
+
+// Fields extracts the Strong's numbers of a tag attribute, which may list
+// several ("strong:H0853 strong:H8064") and mix them with other lemma
+// schemes; bare numbers ("430", as in Zefania) take prefix
+func Fields(value, prefix string) []string {
+	var response []string
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '/'
+	}) {
+		if i := strings.IndexByte(field, ':'); i >= 0 {
+			if !strings.EqualFold(field[:i], "strong") {
+				continue
+			}
+			field = field[i+1:]
+		}
+		if _, err := strconv.Atoi(field); err == nil {
+			field = prefix + field
+		}
+		if number, ok := Normalize(field); ok {
+			response = append(response, number)
+		}
+	}
+	return response
+}
+
+// Prefix is the Strong's prefix of the words of the bookNumber-th
+// canonical book; books outside the Protestant canon are read from the
+// Septuagint, hence Greek
+func Prefix(bookNumber int) string {
+	if bookNumber >= 1 && bookNumber <= len(canon.Books) && canon.Books[bookNumber-1].Testament == canon.TestamentOld {
+		return Hebrew
+	}
+	return Greek
+}
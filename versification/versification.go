@@ -0,0 +1,335 @@
+package versification
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-json"
+	"github.com/roysitumorang/bible/canon"
+)
+
+type (
+	// Interval is an inclusive verse ordinal range,
+	// book_id * 1000000 + chapter * 1000 + verse
+	Interval struct {
+		Start int
+		End   int
+	}
+
+	// Rule maps verses of a scheme to its base scheme. From and To are
+	// OSIS-like ranges ("Ps.3.2-Ps.3", "Mal.4.1"); a To point shifts From
+	// verse by verse, a To range receives the whole of From (merged or
+	// split verses) and an empty To means the verses have no counterpart
+	Rule struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	}
+
+	// Scheme is a versification described as the differences from its
+	// base; the standard scheme has no base and no rules
+	Scheme struct {
+		Code  string  `json:"code"`
+		Name  string  `json:"name"`
+		Base  string  `json:"base"`
+		Rules []*Rule `json:"rules"`
+
+		rules   []*rule
+		inverse []*rule
+	}
+
+	// Registry holds the known schemes and maps references between them
+	// through the standard scheme
+	Registry struct {
+		mu      sync.RWMutex
+		schemes map[string]*Scheme
+	}
+
+	rule struct {
+		from Interval
+		to   *Interval
+	}
+)
+
+const (
+	// Standard is the scheme every other scheme eventually maps to
+	Standard = "kjv"
+
+	ordinalBookFactor    = 1000000
+	ordinalChapterFactor = 1000
+	maxVerse             = ordinalChapterFactor - 1
+)
+
+var (
+	//go:embed data/*.json
+	data embed.FS
+
+	Default = sync.OnceValues(func() (*Registry, error) {
+		registry := NewRegistry()
+		if err := registry.LoadFS(data, "data"); err != nil {
+			return nil, err
+		}
+		return registry, nil
+	})
+
+	ErrUnknownScheme = errors.New("unknown versification")
+
+	books = func() map[string]int {
+		response := make(map[string]int, len(canon.Books))
+		for _, book := range canon.Books {
+			response[book.Code] = book.ID
+		}
+		return response
+	}()
+)
+
+func NewRegistry() *Registry {
+	return &Registry{
+		schemes: map[string]*Scheme{
+			Standard: {
+				Code: Standard,
+				Name: "King James Version",
+			},
+		},
+	}
+}
+
+// LoadFS loads every *.json scheme file found in dir
+func (r *Registry) LoadFS(fsys fs.FS, dir string) error {
+	paths, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, filepath := range paths {
+		content, err := fs.ReadFile(fsys, filepath)
+		if err != nil {
+			return err
+		}
+		var scheme Scheme
+		if err := json.Unmarshal(content, &scheme); err != nil {
+			return fmt.Errorf("versification: %s: %w", filepath, err)
+		}
+		if err := r.Add(&scheme); err != nil {
+			return fmt.Errorf("versification: %s: %w", filepath, err)
+		}
+	}
+	return nil
+}
+
+// Add registers a scheme, replacing an earlier one of the same code
+func (r *Registry) Add(scheme *Scheme) error {
+	if scheme.Code = strings.ToLower(scheme.Code); scheme.Code == "" {
+		return errors.New("code is required")
+	}
+	if scheme.Code == Standard {
+		return fmt.Errorf("%s: standard scheme cannot be replaced", scheme.Code)
+	}
+	if scheme.Base = strings.ToLower(scheme.Base); scheme.Base == "" {
+		scheme.Base = Standard
+	}
+	scheme.rules, scheme.inverse = nil, nil
+	for _, item := range scheme.Rules {
+		from, err := parseInterval(item.From)
+		if err != nil {
+			return fmt.Errorf("%s: %w", scheme.Code, err)
+		}
+		mapping := rule{from: *from}
+		if item.To != "" {
+			if mapping.to, err = parseInterval(item.To); err != nil {
+				return fmt.Errorf("%s: %w", scheme.Code, err)
+			}
+			if !strings.Contains(item.To, "-") && mapping.to.Start == mapping.to.End {
+				// a shift never runs past the end of the target chapter
+				chapterEnd := mapping.to.Start - mapping.to.Start%ordinalChapterFactor + maxVerse
+				mapping.to.End = min(mapping.to.Start+from.End-from.Start, chapterEnd)
+				mapping.from.End = from.Start + mapping.to.End - mapping.to.Start
+			}
+			scheme.inverse = append(scheme.inverse, &rule{from: *mapping.to, to: &mapping.from})
+		}
+		scheme.rules = append(scheme.rules, &mapping)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schemes[scheme.Code] = scheme
+	return nil
+}
+
+func (r *Registry) Scheme(code string) (*Scheme, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	scheme, ok := r.schemes[strings.ToLower(code)]
+	return scheme, ok
+}
+
+func (r *Registry) Schemes() []*Scheme {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	response := make([]*Scheme, 0, len(r.schemes))
+	for _, scheme := range r.schemes {
+		response = append(response, scheme)
+	}
+	sort.Slice(response, func(i, j int) bool {
+		return response[i].Code < response[j].Code
+	})
+	return response
+}
+
+// Map returns the ordinal intervals of scheme to holding the verses
+// numbered start..end in scheme from, in canonical order; verses without
+// a counterpart are left out
+func (r *Registry) Map(from, to string, start, end int) ([]Interval, error) {
+	response := []Interval{{Start: start, End: end}}
+	if strings.EqualFold(from, to) {
+		return response, nil
+	}
+	up, err := r.chain(from)
+	if err != nil {
+		return nil, err
+	}
+	down, err := r.chain(to)
+	if err != nil {
+		return nil, err
+	}
+	// both chains end at the standard scheme, drop their common tail
+	for len(up) > 0 && len(down) > 0 && up[len(up)-1] == down[len(down)-1] {
+		up, down = up[:len(up)-1], down[:len(down)-1]
+	}
+	for _, scheme := range up {
+		response = apply(scheme.rules, response)
+	}
+	for i := len(down) - 1; i >= 0; i-- {
+		response = apply(down[i].inverse, response)
+	}
+	return response, nil
+}
+
+// chain lists code and its bases up to, but excluding, the standard scheme
+func (r *Registry) chain(code string) ([]*Scheme, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var response []*Scheme
+	for code = strings.ToLower(code); code != Standard; {
+		scheme, ok := r.schemes[code]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownScheme, code)
+		}
+		if len(response) == len(r.schemes) {
+			return nil, fmt.Errorf("versification: %s: base cycle", code)
+		}
+		response = append(response, scheme)
+		code = scheme.Base
+	}
+	return response, nil
+}
+
+// apply maps intervals through rules; verses no rule covers keep their
+// number
+func apply(rules []*rule, intervals []Interval) []Interval {
+	var response []Interval
+	for _, interval := range intervals {
+		var covered []Interval
+		for _, rule := range rules {
+			start, end := max(interval.Start, rule.from.Start), min(interval.End, rule.from.End)
+			if start > end {
+				continue
+			}
+			covered = append(covered, Interval{Start: start, End: end})
+			switch {
+			case rule.to == nil:
+			case rule.to.End-rule.to.Start == rule.from.End-rule.from.Start:
+				offset := rule.to.Start - rule.from.Start
+				response = append(response, Interval{Start: start + offset, End: end + offset})
+			default:
+				response = append(response, *rule.to)
+			}
+		}
+		response = append(response, subtract(interval, covered)...)
+	}
+	return merge(response)
+}
+
+func subtract(interval Interval, covered []Interval) []Interval {
+	sort.Slice(covered, func(i, j int) bool {
+		return covered[i].Start < covered[j].Start
+	})
+	var response []Interval
+	next := interval.Start
+	for _, item := range covered {
+		if item.Start > next {
+			response = append(response, Interval{Start: next, End: item.Start - 1})
+		}
+		next = max(next, item.End+1)
+	}
+	if next <= interval.End {
+		response = append(response, Interval{Start: next, End: interval.End})
+	}
+	return response
+}
+
+func merge(intervals []Interval) []Interval {
+	if len(intervals) < 2 {
+		return intervals
+	}
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].Start < intervals[j].Start
+	})
+	response := intervals[:1]
+	for _, item := range intervals[1:] {
+		last := &response[len(response)-1]
+		if item.Start > last.End+1 {
+			response = append(response, item)
+			continue
+		}
+		last.End = max(last.End, item.End)
+	}
+	return response
+}
+
+// parseInterval reads "Book.C[.V][-Book.C[.V]]"; a missing start verse
+// is 1 and a missing end verse the end of the chapter
+func parseInterval(value string) (*Interval, error) {
+	start, end, found := strings.Cut(value, "-")
+	if !found {
+		end = start
+	}
+	startOrdinal, err := parsePoint(start, 1)
+	if err != nil {
+		return nil, err
+	}
+	endOrdinal, err := parsePoint(end, maxVerse)
+	if err != nil {
+		return nil, err
+	}
+	if startOrdinal > endOrdinal {
+		return nil, fmt.Errorf("%s: start after end", value)
+	}
+	return &Interval{Start: startOrdinal, End: endOrdinal}, nil
+}
+
+func parsePoint(value string, defaultVerse int) (int, error) {
+	parts := strings.Split(value, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("%s: expected Book.Chapter[.Verse]", value)
+	}
+	bookID, ok := books[parts[0]]
+	if !ok {
+		return 0, fmt.Errorf("%s: unknown book code %q", value, parts[0])
+	}
+	chapter, err := strconv.Atoi(parts[1])
+	if err != nil || chapter < 1 || chapter >= ordinalChapterFactor {
+		return 0, fmt.Errorf("%s: invalid chapter", value)
+	}
+	verse := defaultVerse
+	if len(parts) == 3 {
+		if verse, err = strconv.Atoi(parts[2]); err != nil || verse < 1 || verse > maxVerse {
+			return 0, fmt.Errorf("%s: invalid verse", value)
+		}
+	}
+	return bookID*ordinalBookFactor + chapter*ordinalChapterFactor + verse, nil
+}
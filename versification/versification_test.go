@@ -0,0 +1,148 @@
+package versification
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// at is the ordinal of book chapter:verse
+func at(book string, chapter, verse int) int {
+	return books[book]*ordinalBookFactor + chapter*ordinalChapterFactor + verse
+}
+
+func span(book string, chapter, start, end int) Interval {
+	return Interval{Start: at(book, chapter, start), End: at(book, chapter, end)}
+}
+
+func TestMap(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Add(&Scheme{
+		Code: "test",
+		Rules: []*Rule{
+			{From: "Gen.1.1", To: ""},
+			{From: "Gen.1.2-Gen.1", To: "Gen.1.1"},
+			{From: "Gen.2.1-Gen.2.2", To: "Gen.2.1-Gen.2.1"},
+			{From: "Gen.3", To: "Gen.4"},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		from, to   string
+		start, end int
+		want       []Interval
+	}{
+		// a title verse without counterpart is dropped, the rest shifted
+		{"test", Standard, at("Gen", 1, 1), at("Gen", 1, 5), []Interval{span("Gen", 1, 1, 4)}},
+		{Standard, "test", at("Gen", 1, 1), at("Gen", 1, 4), []Interval{span("Gen", 1, 2, 5)}},
+		// two verses merged into one, and back
+		{"test", Standard, at("Gen", 2, 2), at("Gen", 2, 2), []Interval{span("Gen", 2, 1, 1)}},
+		{Standard, "test", at("Gen", 2, 1), at("Gen", 2, 1), []Interval{span("Gen", 2, 1, 2)}},
+		// whole chapter renumbered
+		{"test", Standard, at("Gen", 3, 1), at("Gen", 3, 20), []Interval{span("Gen", 4, 1, 20)}},
+		{Standard, "test", at("Gen", 4, 7), at("Gen", 4, 7), []Interval{span("Gen", 3, 7, 7)}},
+		// untouched verses keep their number
+		{"test", Standard, at("Exod", 1, 1), at("Exod", 1, 3), []Interval{span("Exod", 1, 1, 3)}},
+		{"TEST", "test", at("Gen", 1, 1), at("Gen", 1, 1), []Interval{span("Gen", 1, 1, 1)}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s-%s-%d", tt.from, tt.to, tt.start), func(t *testing.T) {
+			got, err := registry.Map(tt.from, tt.to, tt.start, tt.end)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Map(%s, %s, %d, %d) = %v, want %v", tt.from, tt.to, tt.start, tt.end, got, tt.want)
+			}
+		})
+	}
+	if _, err := registry.Map("test", "unknown", at("Gen", 1, 1), at("Gen", 1, 1)); !errors.Is(err, ErrUnknownScheme) {
+		t.Errorf("Map to an unknown scheme error = %v, want %v", err, ErrUnknownScheme)
+	}
+}
+
+func TestDefault(t *testing.T) {
+	registry, err := Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, code := range []string{Standard, "mt", "lxx", "vulgate"} {
+		if _, ok := registry.Scheme(code); !ok {
+			t.Errorf("scheme %s not loaded", code)
+		}
+	}
+	tests := []struct {
+		name       string
+		from, to   string
+		start, end int
+		want       []Interval
+	}{
+		{
+			name: "Ps 3 title has no counterpart",
+			from: "mt", to: Standard,
+			start: at("Ps", 3, 1), end: at("Ps", 3, 9),
+			want: []Interval{span("Ps", 3, 1, 8)},
+		},
+		{
+			name: "Ps 3 back to the Hebrew numbering",
+			from: Standard, to: "mt",
+			start: at("Ps", 3, 1), end: at("Ps", 3, 8),
+			want: []Interval{span("Ps", 3, 2, 9)},
+		},
+		{
+			name: "Mal 4 is Mal 3:19-24 in the Hebrew text",
+			from: Standard, to: "mt",
+			start: at("Mal", 4, 1), end: at("Mal", 4, 6),
+			want: []Interval{span("Mal", 3, 19, 24)},
+		},
+		{
+			name: "Mal 3:19-24 is Mal 4 in English",
+			from: "mt", to: Standard,
+			start: at("Mal", 3, 19), end: at("Mal", 3, 24),
+			want: []Interval{span("Mal", 4, 1, 6)},
+		},
+		{
+			name: "Mal 4 of the Vulgate through its bases",
+			from: "vulgate", to: Standard,
+			start: at("Mal", 4, 1), end: at("Mal", 4, 6),
+			want: []Interval{span("Mal", 4, 1, 6)},
+		},
+		{
+			name: "Ps 23 is Ps 22 in the Septuagint",
+			from: Standard, to: "lxx",
+			start: at("Ps", 23, 1), end: at("Ps", 23, 6),
+			want: []Interval{span("Ps", 22, 1, 6)},
+		},
+		{
+			name: "Ps 22 of the Septuagint is Ps 23",
+			from: "lxx", to: Standard,
+			start: at("Ps", 22, 1), end: at("Ps", 22, 6),
+			want: []Interval{span("Ps", 23, 1, 6)},
+		},
+		{
+			name: "1Sam 21:1 merges into 20:42",
+			from: "mt", to: Standard,
+			start: at("1Sam", 21, 1), end: at("1Sam", 21, 3),
+			want: []Interval{span("1Sam", 20, 42, 42), span("1Sam", 21, 1, 2)},
+		},
+		{
+			name: "1Sam 20:42 splits back into two verses",
+			from: Standard, to: "mt",
+			start: at("1Sam", 20, 42), end: at("1Sam", 20, 42),
+			want: []Interval{{Start: at("1Sam", 20, 42), End: at("1Sam", 21, 1)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := registry.Map(tt.from, tt.to, tt.start, tt.end)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Map(%s, %s, %d, %d) = %v, want %v", tt.from, tt.to, tt.start, tt.end, got, tt.want)
+			}
+		})
+	}
+}